@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"strconv"
 
 	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/logging"
 	"github.com/1kaius1/Timeclock/storage"
 	"github.com/1kaius1/Timeclock/ui"
 )
@@ -57,6 +59,7 @@ func main() {
 	dbFlag := flag.String("db", "", "Path to tracker.db (overrides default).")
 	scaleFlag := flag.Float64("scale", 0, "UI scale factor (0.5 to 3.0, overrides database setting, 0 = use database)")
 	versionFlag := flag.Bool("version", false, "Show version information")
+	logLevelFlag := flag.String("log-level", "", "Log verbosity: debug, info, warn or error (overrides database setting)")
 	flag.Parse()
 
 	// Handle version flag
@@ -75,19 +78,57 @@ func main() {
 		dbPath = *dbFlag
 	}
 
-	if err := ensureDir(dbPath); err != nil {
-		log.Fatalf("failed to create db directory: %v", err)
+	if dbPath != ":memory:" {
+		if err := ensureDir(dbPath); err != nil {
+			log.Fatalf("failed to create db directory: %v", err)
+		}
+		// The log lives next to the database it describes
+		if err := logging.Init(filepath.Join(filepath.Dir(dbPath), "timeclock.log")); err != nil {
+			log.Printf("log file disabled: %v", err)
+		}
+		defer logging.Close()
+	}
+	if *logLevelFlag != "" {
+		lvl, err := logging.ParseLevel(*logLevelFlag)
+		if err != nil {
+			log.Fatalf("-log-level: %v", err)
+		}
+		logging.SetLevel(lvl)
+	}
+	logging.Infof("%s %s starting with database %s", appName, appVersion, dbPath)
+
+	// Headless subcommands print and exit without the GUI. They only read, so
+	// they never migrate or write to a database the GUI may have open.
+	if flag.Arg(0) == "today" {
+		db, err := storage.OpenReadOnly(dbPath)
+		if err != nil {
+			log.Fatalf("today: %v", err)
+		}
+		defer db.Close()
+		if err := runToday(os.Stdout, db); err != nil {
+			log.Fatalf("today: %v", err)
+		}
+		return
 	}
 
 	// Open DB and run migrations
 	db, err := storage.OpenAndMigrate(dbPath)
+	if errors.Is(err, storage.ErrSchemaTooNew) {
+		log.Fatalf("%s: %v", dbPath, err)
+	}
 	if err != nil {
 		log.Fatalf("failed to open/migrate db: %v", err)
 	}
 	defer db.Close()
 
+	if *logLevelFlag == "" {
+		if lvl, err := logging.ParseLevel(storage.GetSetting(db, "log_level", "info")); err == nil {
+			logging.SetLevel(lvl)
+		}
+	}
+
 	// Initialize domain state
-	appState := domain.NewAppState(db)
+	appState := domain.NewAppState(db, appVersion)
 
 	// Restore state from database (handles interrupted sessions)
 	if err := appState.RestoreState(); err != nil {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// runToday prints today's totals by category and the current tracking state,
+// for use from a shell prompt or status bar. Totals cover closed intervals; the
+// running interval's elapsed time is shown separately and added to the total.
+func runToday(w io.Writer, db *sql.DB) error {
+	roundToMinute := storage.GetSetting(db, "exact_durations", "false") != "true"
+	fmtSecs := func(secs int64) string {
+		return reporting.FormatDuration(time.Duration(secs)*time.Second, roundToMinute)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	totals, err := reporting.TotalsByCategory(db, today, today, nil)
+	if err != nil {
+		return err
+	}
+	status, err := domain.ReadStatus(db)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Today (%s)\n", today)
+	switch status.State {
+	case domain.InProgress:
+		fmt.Fprintf(w, "In-Progress: %s %q (%s)\n", status.Category, status.Description, reporting.FormatDuration(status.Elapsed, roundToMinute))
+	case domain.Paused:
+		fmt.Fprintf(w, "Paused: %s %q\n", status.Category, status.Description)
+	default:
+		fmt.Fprintln(w, "Stopped")
+	}
+	fmt.Fprintln(w)
+
+	var total int64
+	for _, t := range totals {
+		fmt.Fprintf(w, "%-14s : %s\n", t.Category, fmtSecs(t.TotalSeconds))
+		total += t.TotalSeconds
+	}
+	if len(totals) == 0 {
+		fmt.Fprintln(w, "(No closed intervals today)")
+	}
+	if status.State == domain.InProgress {
+		fmt.Fprintf(w, "%-14s : %s\n", "Running", reporting.FormatDuration(status.Elapsed, roundToMinute))
+		total += int64(status.Elapsed / time.Second)
+	}
+	fmt.Fprintf(w, "%-14s : %s\n", "Total", fmtSecs(total))
+	return nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDurationInput parses a duration typed by the user. Accepted forms:
+//
+//	1.5h, 90m, 1h30m  (Go duration syntax, decimals allowed)
+//	1:30              (hours:minutes)
+//	90, 7.5           (plain minutes)
+//
+// Negative durations are rejected.
+func ParseDurationInput(s string) (time.Duration, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	if in == "" {
+		return 0, fmt.Errorf("duration is empty")
+	}
+	invalid := fmt.Errorf("invalid duration %q: use e.g. 1.5h, 90m, 1:30, or plain minutes", s)
+
+	var d time.Duration
+	switch {
+	case strings.Contains(in, ":"):
+		hh, mm, _ := strings.Cut(in, ":")
+		h, errH := strconv.Atoi(hh)
+		m, errM := strconv.Atoi(mm)
+		if errH != nil || errM != nil || len(mm) != 2 || m > 59 {
+			return 0, invalid
+		}
+		d = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+
+	case strings.IndexFunc(in, func(r rune) bool { return r >= 'a' && r <= 'z' }) >= 0:
+		var err error
+		d, err = time.ParseDuration(strings.ReplaceAll(in, " ", ""))
+		if err != nil {
+			return 0, invalid
+		}
+
+	default:
+		mins, err := strconv.ParseFloat(in, 64)
+		if err != nil {
+			return 0, invalid
+		}
+		d = time.Duration(mins * float64(time.Minute))
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("duration %q is negative", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDurationInput(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		// Go duration syntax, decimals allowed
+		{"1.5h", 90 * time.Minute},
+		{"90m", 90 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"1h 30m", 90 * time.Minute},
+		{"30s", 30 * time.Second},
+		{" 2H ", 2 * time.Hour},
+		// hours:minutes
+		{"1:30", 90 * time.Minute},
+		{"0:05", 5 * time.Minute},
+		{"10:00", 10 * time.Hour},
+		// plain minutes
+		{"90", 90 * time.Minute},
+		{"7.5", 7*time.Minute + 30*time.Second},
+		{"0", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseDurationInput(tt.in)
+		if err != nil {
+			t.Errorf("ParseDurationInput(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDurationInput(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDurationInputRejectsGarbage(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"   ",
+		"abc",
+		"1.5x",
+		"1:3",  // minutes need two digits
+		"1:60", // minutes out of range
+		"1:30:00",
+		"a:30",
+		"-5",
+		"-1h",
+		"-1:30",
+	} {
+		if d, err := ParseDurationInput(in); err == nil {
+			t.Errorf("ParseDurationInput(%q) = %v, want an error", in, d)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// GoalKind says which side of a category goal counts as meeting it.
+type GoalKind string
+
+const (
+	GoalAtLeast GoalKind = "min" // e.g. at least 4h of Project work
+	GoalAtMost  GoalKind = "max" // e.g. at most 1h of Incident work
+)
+
+// CategoryGoal is a daily target for one category.
+type CategoryGoal struct {
+	Kind   GoalKind
+	Target time.Duration
+}
+
+// Met reports whether total satisfies the goal.
+func (g CategoryGoal) Met(total time.Duration) bool {
+	if g.Kind == GoalAtMost {
+		return total <= g.Target
+	}
+	return total >= g.Target
+}
+
+// String encodes the goal for storage as "<kind>:<minutes>", e.g. "min:240".
+func (g CategoryGoal) String() string {
+	return fmt.Sprintf("%s:%d", g.Kind, int(g.Target/time.Minute))
+}
+
+// ParseCategoryGoal decodes a goal stored by CategoryGoal.String.
+// The target accepts anything ParseDurationInput does.
+func ParseCategoryGoal(s string) (CategoryGoal, error) {
+	kind, target, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return CategoryGoal{}, fmt.Errorf("invalid goal %q", s)
+	}
+	g := CategoryGoal{Kind: GoalKind(kind)}
+	if g.Kind != GoalAtLeast && g.Kind != GoalAtMost {
+		return CategoryGoal{}, fmt.Errorf("invalid goal kind %q", kind)
+	}
+	d, err := ParseDurationInput(target)
+	if err != nil {
+		return CategoryGoal{}, err
+	}
+	g.Target = d
+	return g, nil
+}
+
+// CategoryGoalSettingKey is the settings key holding a category's daily goal.
+func CategoryGoalSettingKey(category string) string {
+	return storage.CategoryGoalKeyPrefix + category
+}
+
+// MonthlyBudgetSettingKey is the settings key holding a category's monthly
+// budget, stored as entered (anything ParseDurationInput accepts).
+func MonthlyBudgetSettingKey(category string) string {
+	return storage.MonthlyBudgetKeyPrefix + category
+}
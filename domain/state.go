@@ -3,10 +3,13 @@ package domain
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/1kaius1/Timeclock/logging"
 	"github.com/1kaius1/Timeclock/storage"
 )
 
@@ -20,9 +23,25 @@ const (
 	Paused
 )
 
+// MaxBlockDuration is the longest fixed block LogBlock accepts.
+const MaxBlockDuration = 12 * time.Hour
+
+// CheckpointInterval is how often the open interval's last_seen_utc is refreshed.
+// After a crash, an interval whose checkpoint is older than two intervals is
+// closed at its last checkpoint instead of being counted up to "now".
+const CheckpointInterval = time.Minute
+
 var (
 	ErrInvalidTransition = errors.New("invalid transition for current state")
 	ErrNoOpenInterval    = errors.New("no open interval to close")
+
+	// ErrIntervalDiscarded is returned by PauseWork/StopWork when the interval was
+	// shorter than the minimum and was dropped. The transition itself succeeded.
+	ErrIntervalDiscarded = errors.New("interval shorter than the minimum was discarded")
+
+	// ErrInvalidStopTime is returned by StopWorkAt when the stop time lies in
+	// the future or before the current interval started.
+	ErrInvalidStopTime = errors.New("invalid stop time")
 )
 
 // AppState holds current UI/business state.
@@ -36,21 +55,54 @@ type AppState struct {
 	// Snapshot set at session start (and carried through until STOP):
 	Category    string // locked in InProgress/Paused
 	Description string // locked in InProgress/Paused
+	IssueID     string // optional ticket/issue id, locked like Category
+	Label       string // optional short session label (e.g. "Morning block"), locked like Category
+	Billable    bool   // whether the session counts as billable; see SetBillable
+
+	// AppVersion is the running Timeclock version, recorded on every event.
+	AppVersion string
 
 	// Interval info:
 	IntervalIndex int       // 0..n within the session
 	IntervalStart time.Time // UTC time when current interval started
 
 	// Preferences:
-	RoundToNearestMinute bool // default true; UI toggle can change this
+	RoundToNearestMinute bool // default true; change with SetRoundToNearestMinute
+
+	// RestoredInProgress is set by RestoreState when it reopened an interrupted
+	// InProgress interval, so the UI can ask the user what to do with it.
+	RestoredInProgress bool
+	// RestoredLastSeen is the last checkpoint of that interval, the latest time
+	// it is known to have been tracked; zero if none was recorded.
+	RestoredLastSeen time.Time
+
+	// now is the clock transitions and elapsed times are taken from; tests
+	// replace it to control time.
+	now func() time.Time
+}
+
+// StateSnapshot is a consistent copy of the fields the UI displays,
+// taken under the AppState mutex.
+type StateSnapshot struct {
+	State       State
+	SessionID   string        // "" when Stopped
+	Elapsed     time.Duration // current interval elapsed (0 unless InProgress)
+	Category    string
+	Description string
+
+	RoundToNearestMinute bool // display preference, see SetRoundToNearestMinute
 }
 
-// NewAppState constructs an initial state (Stopped).
-func NewAppState(db *sql.DB) *AppState {
+// NewAppState constructs an initial state (Stopped). appVersion is stored on
+// the events it writes.
+func NewAppState(db *sql.DB, appVersion string) *AppState {
 	return &AppState{
 		DB:                   db,
+		AppVersion:           appVersion,
 		CurrentState:         Stopped,
+		Billable:             true,
 		RoundToNearestMinute: true,
+		now:                  time.Now,
 	}
 }
 
@@ -60,30 +112,43 @@ func (s *AppState) RestoreState() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// A clean shutdown means any open interval was deliberately left running in
+	// the background; otherwise the previous run crashed or lost power.
+	cleanShutdown := storage.GetSetting(s.DB, "clean_shutdown", "true") == "true"
+	if err := storage.SetSetting(s.DB, "clean_shutdown", "false"); err != nil {
+		return err
+	}
+
 	// Check for open interval
 	var sessionID, category, description string
 	var intervalIndex int
 	var startUTC int64
+	var lastSeenUTC sql.NullInt64
+	var issueID, label sql.NullString
+	var billable bool
 
 	err := s.DB.QueryRow(`
-SELECT session_id, interval_index, start_utc, category, description
+SELECT session_id, interval_index, start_utc, category, description, last_seen_utc, issue_id, label, billable
 FROM intervals
 WHERE end_utc IS NULL
 ORDER BY id DESC
 LIMIT 1;
-`).Scan(&sessionID, &intervalIndex, &startUTC, &category, &description)
+`).Scan(&sessionID, &intervalIndex, &startUTC, &category, &description, &lastSeenUTC, &issueID, &label, &billable)
 
 	if err == sql.ErrNoRows {
 		// No open interval, check if there's a paused session
 		var lastAction string
 		var lastSessionID, lastCategory, lastDescription string
+		var lastIssueID, lastLabel sql.NullString
+		var lastBillable bool
 		
 		err := s.DB.QueryRow(`
-SELECT session_id, action, category, description
+SELECT session_id, action, category, description, issue_id, label, billable
 FROM events
+WHERE deleted_at IS NULL
 ORDER BY id DESC
 LIMIT 1;
-`).Scan(&lastSessionID, &lastAction, &lastCategory, &lastDescription)
+`).Scan(&lastSessionID, &lastAction, &lastCategory, &lastDescription, &lastIssueID, &lastLabel, &lastBillable)
 		
 		if err == sql.ErrNoRows {
 			// No events at all, stay in Stopped state
@@ -98,6 +163,9 @@ LIMIT 1;
 			s.SessionID = lastSessionID
 			s.Category = lastCategory
 			s.Description = lastDescription
+			s.IssueID = lastIssueID.String
+			s.Label = lastLabel.String
+			s.Billable = lastBillable
 			s.CurrentState = Paused
 			// Note: IntervalIndex will be incremented when user hits Resume
 			return nil
@@ -116,19 +184,76 @@ LIMIT 1;
 	s.IntervalStart = time.Unix(startUTC, 0).UTC()
 	s.Category = category
 	s.Description = description
+	s.IssueID = issueID.String
+	s.Label = label.String
+	s.Billable = billable
 	s.CurrentState = InProgress
 
+	// After a crash, don't trust the unbounded gap: close the interval at its
+	// last checkpoint and leave the session Paused so the user can resume it.
+	if !cleanShutdown && lastSeenUTC.Valid {
+		lastSeen := time.Unix(lastSeenUTC.Int64, 0).UTC()
+		if s.now().Sub(lastSeen) > 2*CheckpointInterval {
+			if err := storage.CloseOpenIntervalAndSliceDays(s.DB, s.SessionID, s.IntervalStart, lastSeen, s.Category, s.Description); err != nil {
+				return err
+			}
+			if err := storage.InsertEvent(s.DB, s.SessionID, lastSeen, "PAUSE", s.Category, s.Description, s.IssueID, s.Label, s.AppVersion, s.Billable); err != nil {
+				return err
+			}
+			logging.Warnf("session %s was not shut down cleanly; closed its interval at the last checkpoint %s",
+				s.SessionID, lastSeen.Format(time.RFC3339))
+			s.IntervalStart = time.Time{}
+			s.CurrentState = Paused
+		}
+	}
+	s.RestoredInProgress = s.CurrentState == InProgress
+	if s.RestoredInProgress && lastSeenUTC.Valid {
+		s.RestoredLastSeen = time.Unix(lastSeenUTC.Int64, 0).UTC()
+	}
+
 	return nil
 }
 
+// transitioned logs a state change of the current session and notifies the
+// webhook. Callers hold s.mu.
+func (s *AppState) transitioned(action string, at time.Time) {
+	logging.Infof("%s session %s (%s) at %s", action, s.SessionID, s.Category, at.Format(time.RFC3339))
+	s.fireWebhook(action, at)
+}
+
+// Checkpoint refreshes the open interval's last_seen_utc. The UI ticker calls it
+// every CheckpointInterval; it is a no-op unless InProgress.
+func (s *AppState) Checkpoint() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.CurrentState != InProgress {
+		return nil
+	}
+	return storage.CheckpointOpenInterval(s.DB, s.SessionID, s.now().UTC())
+}
+
+// Shutdown records a final checkpoint and marks the exit as clean, so an
+// interval left running in the background is not capped on next launch.
+func (s *AppState) Shutdown() error {
+	if err := s.Checkpoint(); err != nil {
+		return err
+	}
+	return storage.SetSetting(s.DB, "clean_shutdown", "true")
+}
+
 // StartWork starts a new session (from Stopped) or resumes (from Paused).
 // When starting from Stopped: new session_id, index=0, open interval.
 // When resuming from Paused: same session_id, index++, open interval.
-func (s *AppState) StartWork(description, category string) error {
+// issueID and label are optional. On resume all arguments are ignored: the
+// session keeps the category, description, issue and label it was started with,
+// whatever the caller's widgets currently show.
+// A new session is billable according to s.Billable (see SetBillable).
+func (s *AppState) StartWork(description, category, issueID, label string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	nowUTC := time.Now().UTC()
+	nowUTC := s.now().UTC()
 
 	switch s.CurrentState {
 	case Stopped:
@@ -141,16 +266,19 @@ func (s *AppState) StartWork(description, category string) error {
 		s.IntervalIndex = 0
 		s.Description = description
 		s.Category = category
+		s.IssueID = issueID
+		s.Label = label
 		s.IntervalStart = nowUTC
 		s.CurrentState = InProgress
 
 		// Log START event and open interval
-		if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "START", s.Category, s.Description); err != nil {
+		if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "START", s.Category, s.Description, s.IssueID, s.Label, s.AppVersion, s.Billable); err != nil {
 			return err
 		}
-		if err := storage.OpenInterval(s.DB, s.SessionID, s.IntervalIndex, s.IntervalStart, s.Category, s.Description); err != nil {
+		if err := storage.OpenInterval(s.DB, s.SessionID, s.IntervalIndex, s.IntervalStart, s.Category, s.Description, s.IssueID, s.Label, s.Billable); err != nil {
 			return err
 		}
+		s.transitioned("START", nowUTC)
 		return nil
 
 	case Paused:
@@ -159,12 +287,13 @@ func (s *AppState) StartWork(description, category string) error {
 		s.IntervalStart = nowUTC
 		s.CurrentState = InProgress
 
-		if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "RESUME", s.Category, s.Description); err != nil {
+		if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "RESUME", s.Category, s.Description, s.IssueID, s.Label, s.AppVersion, s.Billable); err != nil {
 			return err
 		}
-		if err := storage.OpenInterval(s.DB, s.SessionID, s.IntervalIndex, s.IntervalStart, s.Category, s.Description); err != nil {
+		if err := storage.OpenInterval(s.DB, s.SessionID, s.IntervalIndex, s.IntervalStart, s.Category, s.Description, s.IssueID, s.Label, s.Billable); err != nil {
 			return err
 		}
+		s.transitioned("RESUME", nowUTC)
 		return nil
 
 	case InProgress:
@@ -174,6 +303,63 @@ func (s *AppState) StartWork(description, category string) error {
 	}
 }
 
+// SetBillable marks the current session billable or not, including the time
+// already recorded for it. While Stopped it only sets the flag the next session
+// starts with.
+func (s *AppState) SetBillable(billable bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Billable = billable
+	if s.CurrentState == Stopped {
+		return nil
+	}
+	return storage.SetSessionBillable(s.DB, s.SessionID, billable)
+}
+
+// ContinueSession reopens a stopped session: it resumes with the session's
+// category/description in a new interval after its last one, writing a RESUME
+// event, so reports treat the reopened work as part of the same session.
+// The app must be Stopped and the session must have ended with STOP.
+func (s *AppState) ContinueSession(sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.CurrentState != Stopped {
+		return ErrInvalidTransition
+	}
+	info, err := storage.GetSession(s.DB, sessionID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	if err != nil {
+		return err
+	}
+	if !info.Stopped() {
+		return fmt.Errorf("session %s is not stopped", sessionID)
+	}
+
+	nowUTC := s.now().UTC()
+	s.SessionID = info.ID
+	s.IntervalIndex = info.LastIndex + 1
+	s.Description = info.Description
+	s.Category = info.Category
+	s.IssueID = info.IssueID
+	s.Label = info.Label
+	s.Billable = info.Billable
+	s.IntervalStart = nowUTC
+	s.CurrentState = InProgress
+
+	if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "RESUME", s.Category, s.Description, s.IssueID, s.Label, s.AppVersion, s.Billable); err != nil {
+		return err
+	}
+	if err := storage.OpenInterval(s.DB, s.SessionID, s.IntervalIndex, s.IntervalStart, s.Category, s.Description, s.IssueID, s.Label, s.Billable); err != nil {
+		return err
+	}
+	s.transitioned("RESUME", nowUTC)
+	return nil
+}
+
 // PauseWork pauses an in-progress session: closes the current interval and stays in the same session.
 // Description/Category remain locked; Start becomes "Resume".
 func (s *AppState) PauseWork() error {
@@ -184,22 +370,156 @@ func (s *AppState) PauseWork() error {
 		return ErrInvalidTransition
 	}
 
-	nowUTC := time.Now().UTC()
+	nowUTC := s.now().UTC()
+
+	// A too-short interval is noise: drop it instead of recording a break
+	if s.isNoiseInterval(nowUTC) {
+		logging.Debugf("discarding interval %d of session %s shorter than the minimum", s.IntervalIndex, s.SessionID)
+		lastAction, err := storage.DiscardOpenInterval(s.DB, s.SessionID)
+		if err != nil {
+			return err
+		}
+		s.IntervalIndex--
+		s.IntervalStart = time.Time{}
+		if lastAction == "PAUSE" {
+			s.CurrentState = Paused
+		} else {
+			// Nothing (or only a finished session) is left: back to Stopped
+			s.resetSession()
+		}
+		return ErrIntervalDiscarded
+	}
 
 	// Close current interval and write PAUSE event
 	if err := storage.CloseOpenIntervalAndSliceDays(s.DB, s.SessionID, s.IntervalStart, nowUTC, s.Category, s.Description); err != nil {
 		return err
 	}
-	if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "PAUSE", s.Category, s.Description); err != nil {
+	if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "PAUSE", s.Category, s.Description, s.IssueID, s.Label, s.AppVersion, s.Billable); err != nil {
 		return err
 	}
 
 	s.CurrentState = Paused
+	s.transitioned("PAUSE", nowUTC)
 	return nil
 }
 
+// AdjustLastInterval corrects the bounds of the most recently closed interval,
+// e.g. when work actually started late or stopped early. The interval's day
+// slices are rebuilt. The new range must be non-empty, not in the future, and
+// must not overlap the interval currently in progress.
+func (s *AppState) AdjustLastInterval(newStart, newEnd time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	newStart, newEnd = newStart.UTC(), newEnd.UTC()
+	if !newStart.Before(newEnd) {
+		return errors.New("start must be before end")
+	}
+	if newEnd.After(s.now().UTC()) {
+		return errors.New("end cannot be in the future")
+	}
+	if s.CurrentState == InProgress && newEnd.After(s.IntervalStart) {
+		return errors.New("adjusted interval would overlap the interval in progress")
+	}
+
+	iv, err := storage.LastClosedInterval(s.DB)
+	if err == sql.ErrNoRows {
+		return errors.New("no closed interval to adjust")
+	}
+	if err != nil {
+		return err
+	}
+	return storage.ResliceInterval(s.DB, iv.ID, newStart, newEnd)
+}
+
+// LogBlock records a completed block of d ending now (e.g. a 30 minute standup)
+// as a session of its own. It is only allowed while Stopped: a running interval
+// would overlap the block, and a paused session must stay the latest one so
+// RestoreState can find it. The block is billable according to s.Billable.
+func (s *AppState) LogBlock(category, description string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if category == "" {
+		return errors.New("category is required")
+	}
+	if d = d.Truncate(time.Second); d <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if d > MaxBlockDuration {
+		return fmt.Errorf("duration must be at most %s", MaxBlockDuration)
+	}
+	if s.CurrentState != Stopped {
+		return errors.New("stop the current session before logging a block")
+	}
+
+	endUTC := time.Unix(s.now().Unix(), 0).UTC()
+	startUTC := endUTC.Add(-d)
+	sessionID := uuid.NewString()
+	if err := storage.InsertCompletedSession(s.DB, sessionID, startUTC, endUTC, category, description, s.AppVersion, s.Billable); err != nil {
+		return err
+	}
+	logging.Infof("logged fixed block of %s (%s) as session %s", d, category, sessionID)
+	return nil
+}
+
+// SplitInterval divides a closed interval at the given time into two, recorded
+// under firstCategory before it and secondCategory after it. at must fall
+// strictly inside the interval.
+func (s *AppState) SplitInterval(intervalID int64, at time.Time, firstCategory, secondCategory string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if firstCategory == "" || secondCategory == "" {
+		return errors.New("both parts need a category")
+	}
+	if err := storage.SplitInterval(s.DB, intervalID, at.UTC(), firstCategory, secondCategory); err != nil {
+		return err
+	}
+	if s.CurrentState == Stopped {
+		return nil
+	}
+	// Splitting an interval of the current session renumbers the ones after it
+	info, err := storage.GetSession(s.DB, s.SessionID)
+	if err != nil {
+		return err
+	}
+	s.IntervalIndex = info.LastIndex
+	return nil
+}
+
+// SetPauseReason records why the current session was paused on its latest
+// PAUSE event. An empty reason clears it.
+func (s *AppState) SetPauseReason(reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.CurrentState != Paused {
+		return ErrInvalidTransition
+	}
+	return storage.SetLastEventReason(s.DB, s.SessionID, "PAUSE", reason)
+}
+
+// SetStopReason records why the given session was stopped on its STOP event.
+// The session ID is passed in because stopping has already cleared it from the
+// state. An empty reason clears it.
+func (s *AppState) SetStopReason(sessionID, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return storage.SetLastEventReason(s.DB, sessionID, "STOP", reason)
+}
+
 // StopWork finalizes the session: closes interval if open and logs STOP.
 func (s *AppState) StopWork() error {
+	return s.StopWorkAt(s.now())
+}
+
+// StopWorkAt finalizes the session as of a past moment, e.g. when the user
+// stopped working before an interrupted session was restored.
+// The time must not precede the current interval's start or lie in the future;
+// otherwise ErrInvalidStopTime is returned.
+func (s *AppState) StopWorkAt(at time.Time) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -207,37 +527,186 @@ func (s *AppState) StopWork() error {
 		return ErrInvalidTransition
 	}
 
-	nowUTC := time.Now().UTC()
+	nowUTC := at.UTC()
+	if nowUTC.After(s.now().UTC()) {
+		return fmt.Errorf("%w: it cannot be in the future", ErrInvalidStopTime)
+	}
+	if s.CurrentState == InProgress && nowUTC.Before(s.IntervalStart) {
+		return fmt.Errorf("%w: it cannot be before the interval started at %s", ErrInvalidStopTime, s.IntervalStart.Local().Format("2006-01-02 15:04"))
+	}
+
+	discarded := false
 
-	// If we were InProgress, close the interval.
-	if s.CurrentState == InProgress {
+	// A too-short interval is noise: drop it, and the STOP too if the session
+	// has nothing left to finish (or was already finished before continuing).
+	if s.CurrentState == InProgress && s.isNoiseInterval(nowUTC) {
+		lastAction, err := storage.DiscardOpenInterval(s.DB, s.SessionID)
+		if err != nil {
+			return err
+		}
+		if lastAction == "" || lastAction == "STOP" {
+			s.resetSession()
+			return ErrIntervalDiscarded
+		}
+		discarded = true
+	} else if s.CurrentState == InProgress {
+		// If we were InProgress, close the interval.
 		if err := storage.CloseOpenIntervalAndSliceDays(s.DB, s.SessionID, s.IntervalStart, nowUTC, s.Category, s.Description); err != nil {
 			return err
 		}
 	}
 
 	// Write STOP event
-	if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "STOP", s.Category, s.Description); err != nil {
+	if err := storage.InsertEvent(s.DB, s.SessionID, nowUTC, "STOP", s.Category, s.Description, s.IssueID, s.Label, s.AppVersion, s.Billable); err != nil {
 		return err
 	}
+	s.transitioned("STOP", nowUTC)
 
-	// Reset session data
+	s.resetSession()
+	if discarded {
+		return ErrIntervalDiscarded
+	}
+	return nil
+}
+
+// resetSession returns to Stopped with no current session.
+// Description & Category become editable again in UI (but we leave last values visible)
+func (s *AppState) resetSession() {
 	s.CurrentState = Stopped
 	s.SessionID = ""
 	s.IntervalIndex = 0
 	s.IntervalStart = time.Time{}
-	// Description & Category become editable again in UI (but we leave last values visible)
-	return nil
+}
+
+// isNoiseInterval reports whether the open interval, ending at end, is shorter
+// than the "min_interval_seconds" setting (0, the default, disables this).
+// An interval exactly at the threshold is kept.
+func (s *AppState) isNoiseInterval(end time.Time) bool {
+	minSeconds, err := strconv.Atoi(storage.GetSetting(s.DB, "min_interval_seconds", "0"))
+	if err != nil || minSeconds <= 0 {
+		return false
+	}
+	return end.Sub(s.IntervalStart) < time.Duration(minSeconds)*time.Second
 }
 
 // Elapsed returns the current interval elapsed (if InProgress).
 func (s *AppState) Elapsed() time.Duration {
+	return s.ElapsedAt(s.now())
+}
+
+// ElapsedAt returns the current interval elapsed as of now, which may be in
+// the future (e.g. to ask what Elapsed would be at a scheduled time). It is 0
+// unless InProgress, and never negative.
+func (s *AppState) ElapsedAt(now time.Time) time.Duration {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.elapsedAt(now)
+}
 
+// elapsedAt is ElapsedAt for callers that hold s.mu.
+func (s *AppState) elapsedAt(now time.Time) time.Duration {
 	if s.CurrentState != InProgress || s.IntervalStart.IsZero() {
 		return 0
 	}
-	return time.Since(s.IntervalStart)
+	if d := now.Sub(s.IntervalStart); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// OpenIntervalInfo describes the interval currently being tracked.
+type OpenIntervalInfo struct {
+	SessionID   string
+	Index       int // interval_index within the session
+	StartUTC    time.Time
+	Category    string
+	Description string
+	Elapsed     time.Duration // as of the call
 }
 
+// CurrentInterval returns the open interval, read under the mutex. The bool is
+// false when no interval is open: while Stopped, and also while Paused.
+func (s *AppState) CurrentInterval() (*OpenIntervalInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.CurrentState != InProgress || s.IntervalStart.IsZero() {
+		return nil, false
+	}
+	return &OpenIntervalInfo{
+		SessionID:   s.SessionID,
+		Index:       s.IntervalIndex,
+		StartUTC:    s.IntervalStart,
+		Category:    s.Category,
+		Description: s.Description,
+		Elapsed:     s.elapsedAt(s.now()),
+	}, true
+}
+
+// SetRoundToNearestMinute changes the duration display preference under the
+// mutex, since the UI ticker reads it through Snapshot on another goroutine.
+func (s *AppState) SetRoundToNearestMinute(round bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.RoundToNearestMinute = round
+}
+
+// Snapshot returns a consistent copy of the current state for readers on other
+// goroutines (e.g. the UI ticker), avoiding unlocked field reads.
+func (s *AppState) Snapshot() StateSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return StateSnapshot{
+		State:                s.CurrentState,
+		SessionID:            s.SessionID,
+		Elapsed:              s.elapsedAt(s.now()),
+		Category:             s.Category,
+		Description:          s.Description,
+		RoundToNearestMinute: s.RoundToNearestMinute,
+	}
+}
+
+// ReadStatus reports the persisted tracking state without modifying anything,
+// for tools that inspect the database while the GUI may be running.
+// Unlike RestoreState it neither caps crashed intervals nor touches settings.
+func ReadStatus(db *sql.DB) (StateSnapshot, error) {
+	var snap StateSnapshot
+	var startUTC int64
+
+	err := db.QueryRow(`
+SELECT session_id, start_utc, category, description
+FROM intervals
+WHERE end_utc IS NULL
+ORDER BY id DESC
+LIMIT 1;
+`).Scan(&snap.SessionID, &startUTC, &snap.Category, &snap.Description)
+	if err == nil {
+		snap.State = InProgress
+		snap.Elapsed = time.Since(time.Unix(startUTC, 0))
+		return snap, nil
+	}
+	if err != sql.ErrNoRows {
+		return snap, err
+	}
+
+	var lastAction string
+	err = db.QueryRow(`
+SELECT session_id, action, category, description
+FROM events
+WHERE deleted_at IS NULL
+ORDER BY id DESC
+LIMIT 1;
+`).Scan(&snap.SessionID, &lastAction, &snap.Category, &snap.Description)
+	if err == sql.ErrNoRows {
+		return StateSnapshot{State: Stopped}, nil
+	}
+	if err != nil {
+		return snap, err
+	}
+	if lastAction == "PAUSE" {
+		snap.State = Paused
+		return snap, nil
+	}
+	return StateSnapshot{State: Stopped}, nil
+}
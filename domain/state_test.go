@@ -0,0 +1,389 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// testClock is a settable clock for AppState.now.
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time          { return c.t }
+func (c *testClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// newTestState returns a Stopped AppState on a fresh in-memory database whose
+// clock only moves when the test advances it.
+func newTestState(t *testing.T) (*AppState, *testClock) {
+	t.Helper()
+	db, err := storage.OpenAndMigrate(":memory:")
+	if err != nil {
+		t.Fatalf("OpenAndMigrate: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	clock := &testClock{t: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+	s := NewAppState(db, "test")
+	s.now = clock.now
+	return s, clock
+}
+
+// countRows returns the number of rows of table belonging to sessionID.
+func countRows(t *testing.T, db *sql.DB, table, sessionID string) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM `+table+` WHERE session_id = ?;`, sessionID).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// eventActions returns the actions of the session's events in order.
+func eventActions(t *testing.T, db *sql.DB, sessionID string) []string {
+	t.Helper()
+	rows, err := db.Query(`SELECT action FROM events WHERE session_id = ? ORDER BY id;`, sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var actions []string
+	for rows.Next() {
+		var a string
+		if err := rows.Scan(&a); err != nil {
+			t.Fatal(err)
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+func TestNoiseIntervalThreshold(t *testing.T) {
+	const minSeconds = 10
+	transitions := []struct {
+		name  string
+		close func(*AppState) error
+		after State // state after keeping the interval
+	}{
+		{"PauseWork", (*AppState).PauseWork, Paused},
+		{"StopWork", (*AppState).StopWork, Stopped},
+	}
+	for _, tr := range transitions {
+		t.Run(tr.name+" below threshold", func(t *testing.T) {
+			s, clock := newTestState(t)
+			if err := storage.SetSetting(s.DB, "min_interval_seconds", "10"); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.StartWork("typo", "Dev", "", ""); err != nil {
+				t.Fatal(err)
+			}
+			id := s.SessionID
+			clock.advance(minSeconds*time.Second - time.Millisecond)
+
+			if err := tr.close(s); !errors.Is(err, ErrIntervalDiscarded) {
+				t.Fatalf("%s error = %v, want ErrIntervalDiscarded", tr.name, err)
+			}
+			if s.CurrentState != Stopped {
+				t.Errorf("state = %v, want Stopped", s.CurrentState)
+			}
+			if n := countRows(t, s.DB, "intervals", id); n != 0 {
+				t.Errorf("%d interval rows left, want 0", n)
+			}
+			if n := countRows(t, s.DB, "interval_days", id); n != 0 {
+				t.Errorf("%d interval_days rows left, want 0", n)
+			}
+			if actions := eventActions(t, s.DB, id); len(actions) != 0 {
+				t.Errorf("events left = %v, want none", actions)
+			}
+		})
+
+		t.Run(tr.name+" exactly at threshold", func(t *testing.T) {
+			s, clock := newTestState(t)
+			if err := storage.SetSetting(s.DB, "min_interval_seconds", "10"); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.StartWork("real work", "Dev", "", ""); err != nil {
+				t.Fatal(err)
+			}
+			id := s.SessionID
+			clock.advance(minSeconds * time.Second)
+
+			if err := tr.close(s); err != nil {
+				t.Fatalf("%s error = %v, want nil", tr.name, err)
+			}
+			if s.CurrentState != tr.after {
+				t.Errorf("state = %v, want %v", s.CurrentState, tr.after)
+			}
+			var duration int64
+			if err := s.DB.QueryRow(`SELECT duration_seconds FROM intervals WHERE session_id = ?;`, id).Scan(&duration); err != nil {
+				t.Fatalf("interval row: %v", err)
+			}
+			if duration != minSeconds {
+				t.Errorf("duration = %d, want %d", duration, minSeconds)
+			}
+			want := "PAUSE"
+			if tr.after == Stopped {
+				want = "STOP"
+			}
+			if actions := eventActions(t, s.DB, id); !slices.Equal(actions, []string{"START", want}) {
+				t.Errorf("events = %v, want [START %s]", actions, want)
+			}
+		})
+	}
+
+	// A short interval after a resume drops the RESUME and its interval, but
+	// the session's earlier work stays and is finished with STOP.
+	t.Run("StopWork after resume", func(t *testing.T) {
+		s, clock := newTestState(t)
+		if err := storage.SetSetting(s.DB, "min_interval_seconds", "10"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.StartWork("real work", "Dev", "", ""); err != nil {
+			t.Fatal(err)
+		}
+		id := s.SessionID
+		clock.advance(time.Minute)
+		if err := s.PauseWork(); err != nil {
+			t.Fatal(err)
+		}
+		clock.advance(time.Minute)
+		if err := s.StartWork("", "", "", ""); err != nil {
+			t.Fatal(err)
+		}
+		clock.advance(5 * time.Second)
+
+		if err := s.StopWork(); !errors.Is(err, ErrIntervalDiscarded) {
+			t.Fatalf("StopWork error = %v, want ErrIntervalDiscarded", err)
+		}
+		if n := countRows(t, s.DB, "intervals", id); n != 1 {
+			t.Errorf("%d interval rows, want 1", n)
+		}
+		if actions := eventActions(t, s.DB, id); !slices.Equal(actions, []string{"START", "PAUSE", "STOP"}) {
+			t.Errorf("events = %v, want [START PAUSE STOP]", actions)
+		}
+	})
+}
+
+// intervalIndexes returns the session's interval_index values in start order.
+func intervalIndexes(t *testing.T, db *sql.DB, sessionID string) []int {
+	t.Helper()
+	rows, err := db.Query(`SELECT interval_index FROM intervals WHERE session_id = ? ORDER BY start_utc;`, sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var indexes []int
+	for rows.Next() {
+		var i int
+		if err := rows.Scan(&i); err != nil {
+			t.Fatal(err)
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+func TestSplitIntervalRenumbersLaterIntervals(t *testing.T) {
+	s, clock := newTestState(t)
+	if err := s.StartWork("", "Dev", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	id := s.SessionID
+	clock.advance(time.Hour)
+	if err := s.PauseWork(); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(10 * time.Minute)
+	if err := s.StartWork("", "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(time.Hour)
+
+	// Split the first interval while the second is still open
+	var firstID int64
+	if err := s.DB.QueryRow(`SELECT id FROM intervals WHERE session_id = ? AND interval_index = 0;`, id).Scan(&firstID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SplitInterval(firstID, clock.t.Add(-90*time.Minute), "Dev", "Meetings"); err != nil {
+		t.Fatalf("SplitInterval: %v", err)
+	}
+	if got := intervalIndexes(t, s.DB, id); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("indexes after split = %v, want [0 1 2]", got)
+	}
+
+	// The open interval moved to index 2, so the next one must be 3
+	if err := s.PauseWork(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.StartWork("", "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(time.Minute)
+	if err := s.StopWork(); err != nil {
+		t.Fatal(err)
+	}
+	if got := intervalIndexes(t, s.DB, id); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("indexes after resume = %v, want [0 1 2 3]", got)
+	}
+}
+
+func TestCurrentInterval(t *testing.T) {
+	s, clock := newTestState(t)
+	if info, ok := s.CurrentInterval(); ok || info != nil {
+		t.Fatalf("Stopped: CurrentInterval = %+v, %v; want nil, false", info, ok)
+	}
+
+	if err := s.StartWork("write tests", "Dev", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	start := clock.t
+	clock.advance(time.Hour)
+	info, ok := s.CurrentInterval()
+	if !ok {
+		t.Fatal("InProgress: CurrentInterval reported no open interval")
+	}
+	if info.SessionID != s.SessionID || info.Index != 0 || !info.StartUTC.Equal(start) {
+		t.Errorf("InProgress: session %q index %d start %v, want %q 0 %v",
+			info.SessionID, info.Index, info.StartUTC, s.SessionID, start)
+	}
+	if info.Category != "Dev" || info.Description != "write tests" {
+		t.Errorf("InProgress: category %q description %q, want %q %q",
+			info.Category, info.Description, "Dev", "write tests")
+	}
+
+	if err := s.PauseWork(); err != nil {
+		t.Fatal(err)
+	}
+	if info, ok := s.CurrentInterval(); ok || info != nil {
+		t.Errorf("Paused: CurrentInterval = %+v, %v; want nil, false", info, ok)
+	}
+
+	// Resuming opens the session's next interval
+	clock.advance(10 * time.Minute)
+	if err := s.StartWork("", "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	info, ok = s.CurrentInterval()
+	if !ok || info.Index != 1 || !info.StartUTC.Equal(clock.t) {
+		t.Errorf("resumed: CurrentInterval = %+v, %v; want index 1 starting %v", info, ok, clock.t)
+	}
+}
+
+// TestResumeKeepsSessionCategory restarts with a Paused session whose category
+// has since been removed from the list; whatever category the UI passes on
+// resume, the session keeps its own.
+func TestResumeKeepsSessionCategory(t *testing.T) {
+	for _, passed := range []string{"", "Dev"} {
+		t.Run("passed "+strconv.Quote(passed), func(t *testing.T) {
+			before, clock := newTestState(t)
+			if err := before.StartWork("retainer", "Acme", "", ""); err != nil {
+				t.Fatal(err)
+			}
+			id := before.SessionID
+			clock.advance(time.Hour)
+			if err := before.PauseWork(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Restart: the domain restores from the database alone
+			s := NewAppState(before.DB, "test")
+			s.now = clock.now
+			if err := s.RestoreState(); err != nil {
+				t.Fatalf("RestoreState: %v", err)
+			}
+			if s.CurrentState != Paused || s.SessionID != id || s.Category != "Acme" {
+				t.Fatalf("restored %v session %q category %q, want Paused %q %q",
+					s.CurrentState, s.SessionID, s.Category, id, "Acme")
+			}
+
+			clock.advance(10 * time.Minute)
+			if err := s.StartWork("", passed, "", ""); err != nil {
+				t.Fatalf("resume: %v", err)
+			}
+			if s.Category != "Acme" {
+				t.Errorf("category after resume = %q, want %q", s.Category, "Acme")
+			}
+			var intervalCategory, eventCategory string
+			if err := s.DB.QueryRow(`SELECT category FROM intervals WHERE session_id = ? AND interval_index = 1;`, id).Scan(&intervalCategory); err != nil {
+				t.Fatalf("resumed interval: %v", err)
+			}
+			if err := s.DB.QueryRow(`SELECT category FROM events WHERE session_id = ? AND action = 'RESUME';`, id).Scan(&eventCategory); err != nil {
+				t.Fatalf("RESUME event: %v", err)
+			}
+			if intervalCategory != "Acme" || eventCategory != "Acme" {
+				t.Errorf("resumed interval category %q, RESUME event category %q, want %q",
+					intervalCategory, eventCategory, "Acme")
+			}
+		})
+	}
+}
+
+// TestElapsedReadersAgree checks that every way of reading the elapsed time
+// uses the state's clock and the same rules as ElapsedAt.
+func TestElapsedReadersAgree(t *testing.T) {
+	s, clock := newTestState(t)
+	check := func(when string, want time.Duration) {
+		t.Helper()
+		if got := s.Elapsed(); got != want {
+			t.Errorf("%s: Elapsed = %v, want %v", when, got, want)
+		}
+		if got := s.ElapsedAt(clock.t); got != want {
+			t.Errorf("%s: ElapsedAt = %v, want %v", when, got, want)
+		}
+		if got := s.Snapshot().Elapsed; got != want {
+			t.Errorf("%s: Snapshot.Elapsed = %v, want %v", when, got, want)
+		}
+		var got time.Duration
+		if info, ok := s.CurrentInterval(); ok {
+			got = info.Elapsed
+		}
+		if got != want {
+			t.Errorf("%s: CurrentInterval.Elapsed = %v, want %v", when, got, want)
+		}
+	}
+
+	check("stopped", 0)
+	if err := s.StartWork("", "Dev", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	check("just started", 0)
+	clock.advance(90*time.Minute + 7*time.Second)
+	check("running", 90*time.Minute+7*time.Second)
+
+	// A clock that went backwards never yields a negative elapsed
+	clock.advance(-2 * time.Hour)
+	check("clock behind start", 0)
+	clock.advance(2 * time.Hour)
+
+	if err := s.PauseWork(); err != nil {
+		t.Fatal(err)
+	}
+	check("paused", 0)
+}
+
+func TestStopWorkAtRejectsOutOfRangeTimes(t *testing.T) {
+	s, clock := newTestState(t)
+	if err := s.StartWork("", "Dev", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	start := clock.now()
+	clock.advance(time.Hour)
+
+	for name, at := range map[string]time.Time{
+		"before start": start.Add(-time.Minute),
+		"future":       clock.now().Add(time.Minute),
+	} {
+		if err := s.StopWorkAt(at); !errors.Is(err, ErrInvalidStopTime) {
+			t.Errorf("%s: StopWorkAt = %v, want ErrInvalidStopTime", name, err)
+		}
+	}
+	if s.CurrentState != InProgress {
+		t.Fatalf("state after rejected stops = %v, want InProgress", s.CurrentState)
+	}
+	if err := s.StopWorkAt(start.Add(30 * time.Minute)); err != nil {
+		t.Fatalf("StopWorkAt: %v", err)
+	}
+}
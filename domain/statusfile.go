@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StatusFileInterval is how often the status file is rewritten while the app runs.
+const StatusFileInterval = 5 * time.Second
+
+// StatusFile is the JSON document written for external status bars.
+type StatusFile struct {
+	State          string    `json:"state"` // in_progress, paused, stopped
+	Category       string    `json:"category"`
+	Description    string    `json:"description"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"` // current interval, 0 unless in_progress
+	Updated        time.Time `json:"updated"`
+}
+
+// DefaultStatusFilePath places the status file next to the database, or in the
+// temp directory for an in-memory database.
+func DefaultStatusFilePath(dbPath string) string {
+	dir := os.TempDir()
+	if dbPath != ":memory:" {
+		dir = filepath.Dir(dbPath)
+	}
+	return filepath.Join(dir, "timeclock-status.json")
+}
+
+// WriteStatusFile writes snap to path as a StatusFile. The document is written to
+// a temporary file in the same directory and renamed over path, so readers never
+// see partial JSON. A stopped snapshot is written with empty fields.
+func WriteStatusFile(path string, snap StateSnapshot) error {
+	st := StatusFile{State: "stopped", Updated: time.Now().UTC()}
+	switch snap.State {
+	case InProgress:
+		st.State = "in_progress"
+		st.ElapsedSeconds = int64(snap.Elapsed / time.Second)
+	case Paused:
+		st.State = "paused"
+	}
+	if snap.State != Stopped {
+		st.Category, st.Description = snap.Category, snap.Description
+	}
+
+	body, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".timeclock-status-*")
+	if err != nil {
+		return fmt.Errorf("create temp status file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(append(body, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write status file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write status file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace status file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/1kaius1/Timeclock/logging"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// webhookTimeout bounds each webhook POST so a slow endpoint can't pile up requests.
+const webhookTimeout = 5 * time.Second
+
+// TransitionEvent is the JSON payload POSTed to the webhook on each transition.
+type TransitionEvent struct {
+	SessionID   string    `json:"session_id"`
+	Action      string    `json:"action"` // START, PAUSE, RESUME, STOP
+	Category    string    `json:"category"`
+	Description string    `json:"description"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// PostWebhook sends ev to url as JSON and waits for the response.
+// Any non-2xx status is an error.
+func PostWebhook(url string, ev TransitionEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// fireWebhook posts a transition of the current session to the "webhook_url"
+// setting in the background. It does nothing when no URL is configured, and
+// failures are only logged. Callers hold s.mu.
+func (s *AppState) fireWebhook(action string, at time.Time) {
+	url := storage.GetSetting(s.DB, "webhook_url", "")
+	if url == "" {
+		return
+	}
+	ev := TransitionEvent{
+		SessionID:   s.SessionID,
+		Action:      action,
+		Category:    s.Category,
+		Description: s.Description,
+		Timestamp:   at.UTC(),
+	}
+	go func() {
+		if err := PostWebhook(url, ev); err != nil {
+			logging.Warnf("webhook %s: %v", action, err)
+		}
+	}()
+}
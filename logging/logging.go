@@ -0,0 +1,107 @@
+// Package logging is a small leveled logger over the standard log package. It
+// writes to stderr and, once Init is called, to a size-rotated file as well.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Level orders messages by severity; messages below the configured level are dropped.
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelError {
+		return fmt.Sprintf("level(%d)", int32(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel accepts debug, info, warn or error (case-insensitive).
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if strings.EqualFold(strings.TrimSpace(s), name) {
+			return Level(i), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", s)
+}
+
+var (
+	level  atomic.Int32 // Level; LevelInfo until SetLevel
+	mu     sync.Mutex
+	logger = log.New(os.Stderr, "", log.LstdFlags)
+	file   *rotatingFile
+)
+
+func init() {
+	level.Store(int32(LevelInfo))
+}
+
+// SetLevel changes the minimum level that is written.
+func SetLevel(l Level) {
+	level.Store(int32(l))
+}
+
+// CurrentLevel returns the minimum level that is written.
+func CurrentLevel() Level {
+	return Level(level.Load())
+}
+
+// Init additionally writes the log to path, rotating it once it grows past
+// maxFileSize and keeping maxBackups older files (path.1 is the newest).
+func Init(path string) error {
+	f, err := openRotatingFile(path)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		file.Close()
+	}
+	file = f
+	logger.SetOutput(io.MultiWriter(os.Stderr, f))
+	return nil
+}
+
+// Close flushes and closes the log file, if any. Logging continues on stderr.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	logger.SetOutput(os.Stderr)
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	file = nil
+	return err
+}
+
+func logf(l Level, format string, args ...any) {
+	if l < CurrentLevel() {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	logger.Printf(strings.ToUpper(l.String())+" "+format, args...)
+}
+
+func Debugf(format string, args ...any) { logf(LevelDebug, format, args...) }
+func Infof(format string, args ...any)  { logf(LevelInfo, format, args...) }
+func Warnf(format string, args ...any)  { logf(LevelWarn, format, args...) }
+func Errorf(format string, args ...any) { logf(LevelError, format, args...) }
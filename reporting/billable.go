@@ -0,0 +1,22 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// BillableSummary returns the billable and non-billable duration_seconds for
+// local dates within [fromDate, toDate] inclusive.
+func BillableSummary(db *sql.DB, fromDate, toDate string) (billable, nonBillable int64, err error) {
+	err = db.QueryRow(`
+SELECT COALESCE(SUM(CASE WHEN i.billable THEN d.duration_seconds ELSE 0 END), 0),
+       COALESCE(SUM(CASE WHEN i.billable THEN 0 ELSE d.duration_seconds END), 0)
+FROM interval_days d
+JOIN intervals i ON i.id = d.interval_id
+WHERE d.date_local >= ? AND d.date_local <= ? AND d.deleted_at IS NULL;
+`, fromDate, toDate).Scan(&billable, &nonBillable)
+	if err != nil {
+		return 0, 0, fmt.Errorf("query billable summary: %w", err)
+	}
+	return billable, nonBillable, nil
+}
@@ -0,0 +1,43 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// NoDescriptionLabel stands in for an empty description in breakdowns.
+const NoDescriptionLabel = "(none)"
+
+// DescriptionTotal is the total duration recorded under one description.
+type DescriptionTotal struct {
+	Description  string
+	TotalSeconds int64
+}
+
+// CategoryDescriptionBreakdown returns, per category, duration_seconds summed by
+// description for local dates within [fromDate, toDate] inclusive, largest first.
+// Work without a description is listed as NoDescriptionLabel.
+func CategoryDescriptionBreakdown(db *sql.DB, fromDate, toDate string) (map[string][]DescriptionTotal, error) {
+	rows, err := db.Query(`
+SELECT category, COALESCE(NULLIF(TRIM(description), ''), ?) AS descr, SUM(duration_seconds) AS total_seconds
+FROM interval_days
+WHERE date_local >= ? AND date_local <= ? AND deleted_at IS NULL
+GROUP BY category, descr
+ORDER BY category, total_seconds DESC, descr;
+`, NoDescriptionLabel, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("query category/description breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	res := map[string][]DescriptionTotal{}
+	for rows.Next() {
+		var category string
+		var t DescriptionTotal
+		if err := rows.Scan(&category, &t.Description, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res[category] = append(res[category], t)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,68 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// BreakTotal is the number and total length of breaks taken for one reason.
+// Reason is empty for breaks where no reason was given.
+type BreakTotal struct {
+	Reason       string
+	Count        int
+	TotalSeconds int64
+}
+
+// BreaksByReason sums breaks by their pause reason for breaks that began on local
+// dates within [fromDate, toDate] inclusive. A break runs from a PAUSE event to the
+// session's next event (RESUME or STOP); sessions still paused are not counted.
+func BreaksByReason(db *sql.DB, fromDate, toDate string) ([]BreakTotal, error) {
+	from, toExclusive, err := localDateBounds(db, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+SELECT COALESCE(p.reason, '') AS break_reason, COUNT(*), SUM(n.timestamp_utc - p.timestamp_utc) AS total_seconds
+FROM events p
+JOIN events n
+  ON n.id = (SELECT MIN(id) FROM events WHERE session_id = p.session_id AND id > p.id)
+WHERE p.action = 'PAUSE' AND p.timestamp_utc >= ? AND p.timestamp_utc < ? AND p.deleted_at IS NULL
+GROUP BY break_reason
+ORDER BY total_seconds DESC;
+`, from.Unix(), toExclusive.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query breaks: %w", err)
+	}
+	defer rows.Close()
+
+	var res []BreakTotal
+	for rows.Next() {
+		var b BreakTotal
+		if err := rows.Scan(&b.Reason, &b.Count, &b.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+	return res, rows.Err()
+}
+
+// localDateBounds converts an inclusive 'YYYY-MM-DD' local date range into the
+// instants [from, toExclusive) for filtering on UTC timestamps. Days are taken
+// in the report time zone and start at the day boundary, as in interval_days,
+// so these reports agree with TotalsByDay.
+func localDateBounds(db *sql.DB, fromDate, toDate string) (from, toExclusive time.Time, err error) {
+	loc, boundary := storage.ReportLocation(db), storage.DayBoundary(db)
+	from, _, err = storage.DayBounds(fromDate, loc, boundary)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("parse from date: %w", err)
+	}
+	_, toExclusive, err = storage.DayBounds(toDate, loc, boundary)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("parse to date: %w", err)
+	}
+	return from, toExclusive, nil
+}
@@ -0,0 +1,99 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// DayCategoryTotal is the total duration for one category on one local date.
+type DayCategoryTotal struct {
+	Date         string // 'YYYY-MM-DD'
+	Category     string
+	TotalSeconds int64
+}
+
+// TotalsByDayAndCategory returns duration_seconds summed per local date and category
+// within [fromDate, toDate] inclusive, ordered by date then category.
+// Day/category pairs without any work are omitted.
+func TotalsByDayAndCategory(db *sql.DB, fromDate, toDate string) ([]DayCategoryTotal, error) {
+	rows, err := db.Query(`
+SELECT date_local, category, SUM(duration_seconds) AS total_seconds
+FROM interval_days
+WHERE date_local >= ? AND date_local <= ? AND deleted_at IS NULL
+GROUP BY date_local, category
+ORDER BY date_local, category;
+`, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("query day/category totals: %w", err)
+	}
+	defer rows.Close()
+
+	var res []DayCategoryTotal
+	for rows.Next() {
+		var t DayCategoryTotal
+		if err := rows.Scan(&t.Date, &t.Category, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
+
+// DayTotal is the total duration recorded on one local date.
+type DayTotal struct {
+	Date         string // 'YYYY-MM-DD'
+	TotalSeconds int64
+}
+
+// TotalsByDay returns duration_seconds summed per local date within
+// [fromDate, toDate] inclusive, ordered by date. Days without work are omitted.
+func TotalsByDay(db *sql.DB, fromDate, toDate string) ([]DayTotal, error) {
+	rows, err := db.Query(`
+SELECT date_local, SUM(duration_seconds) AS total_seconds
+FROM interval_days
+WHERE date_local >= ? AND date_local <= ? AND deleted_at IS NULL
+GROUP BY date_local
+ORDER BY date_local;
+`, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("query day totals: %w", err)
+	}
+	defer rows.Close()
+
+	var res []DayTotal
+	for rows.Next() {
+		var t DayTotal
+		if err := rows.Scan(&t.Date, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
+
+// CategoryDailyTotals returns one category's duration_seconds summed per local date
+// within [fromDate, toDate] inclusive, ordered by date. Days without that category
+// are omitted.
+func CategoryDailyTotals(db *sql.DB, category, fromDate, toDate string) ([]DayTotal, error) {
+	rows, err := db.Query(`
+SELECT date_local, SUM(duration_seconds) AS total_seconds
+FROM interval_days
+WHERE category = ? AND date_local >= ? AND date_local <= ? AND deleted_at IS NULL
+GROUP BY date_local
+ORDER BY date_local;
+`, category, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("query category day totals: %w", err)
+	}
+	defer rows.Close()
+
+	var res []DayTotal
+	for rows.Next() {
+		var t DayTotal
+		if err := rows.Scan(&t.Date, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,66 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// LogEntry is one interval as it appears in the day log, clamped to the day.
+type LogEntry struct {
+	StartUTC    time.Time
+	EndUTC      time.Time
+	Seconds     int64 // length within the day
+	Category    string
+	Description string
+	Open        bool // still running; EndUTC is now
+}
+
+// DayLog returns the intervals overlapping local date dateLocal ('YYYY-MM-DD')
+// in start order, read from intervals for exact times. The day is bounded as in
+// TotalsByDay (report time zone and day boundary); an interval crossing its
+// bounds is cut there, and an open interval runs until now.
+func DayLog(db *sql.DB, dateLocal string) ([]LogEntry, error) {
+	dayStart, dayEnd, err := localDateBounds(db, dateLocal, dateLocal)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	rows, err := db.Query(`
+SELECT start_utc, end_utc, category, COALESCE(description, '')
+FROM intervals
+WHERE start_utc < ? AND COALESCE(end_utc, ?) > ? AND deleted_at IS NULL
+ORDER BY start_utc, id;
+`, dayEnd.Unix(), now.Unix(), dayStart.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query day log: %w", err)
+	}
+	defer rows.Close()
+
+	var res []LogEntry
+	for rows.Next() {
+		var e LogEntry
+		var startUnix int64
+		var endUnix sql.NullInt64
+		if err := rows.Scan(&startUnix, &endUnix, &e.Category, &e.Description); err != nil {
+			return nil, err
+		}
+		start, end := time.Unix(startUnix, 0), now
+		if endUnix.Valid {
+			end = time.Unix(endUnix.Int64, 0)
+		} else {
+			e.Open = true
+		}
+		if start.Before(dayStart) {
+			start = dayStart
+		}
+		if end.After(dayEnd) {
+			end = dayEnd
+		}
+		e.StartUTC, e.EndUTC = start.UTC(), end.UTC()
+		e.Seconds = int64(end.Sub(start).Seconds())
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
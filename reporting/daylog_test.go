@@ -0,0 +1,70 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// TestDayLogMatchesDayTotals checks that DayLog cuts the day where interval_days
+// does, in the report time zone and at the day boundary, not at local midnight.
+func TestDayLogMatchesDayTotals(t *testing.T) {
+	db, err := storage.OpenAndMigrate(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for key, value := range map[string]string{
+		"report_timezone": "America/New_York",
+		"day_boundary":    "04:00",
+	} {
+		if err := storage.SetSetting(db, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// 22:00 on the 2nd to 05:00 on the 3rd, New York time: with a 04:00
+	// boundary six hours belong to the 2nd and one to the 3rd.
+	start := time.Date(2026, 3, 2, 22, 0, 0, 0, ny)
+	if err := storage.InsertCompletedSession(db, "late", start.UTC(), start.Add(7*time.Hour).UTC(), "Dev", "release", "test", true); err != nil {
+		t.Fatal(err)
+	}
+
+	totals, err := TotalsByDay(db, "2026-03-02", "2026-03-03")
+	if err != nil {
+		t.Fatalf("TotalsByDay: %v", err)
+	}
+	want := map[string]int64{}
+	for _, d := range totals {
+		want[d.Date] += d.TotalSeconds
+	}
+	for _, day := range []string{"2026-03-02", "2026-03-03"} {
+		entries, err := DayLog(db, day)
+		if err != nil {
+			t.Fatalf("DayLog(%s): %v", day, err)
+		}
+		var got int64
+		for _, e := range entries {
+			got += e.Seconds
+		}
+		if got != want[day] {
+			t.Errorf("DayLog(%s) = %ds, TotalsByDay = %ds", day, got, want[day])
+		}
+	}
+	if want["2026-03-02"] != 6*3600 || want["2026-03-03"] != 3600 {
+		t.Errorf("TotalsByDay = %v, want 6h on the 2nd and 1h on the 3rd", want)
+	}
+
+	entries, err := DayLog(db, "2026-03-03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !entries[0].StartUTC.Equal(time.Date(2026, 3, 3, 4, 0, 0, 0, ny)) {
+		t.Errorf("DayLog(2026-03-03) = %+v, want one entry cut at 04:00 New York time", entries)
+	}
+}
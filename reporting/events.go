@@ -0,0 +1,85 @@
+package reporting
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportEventsCSV writes the raw events (START, PAUSE, RESUME, STOP) whose
+// timestamp falls on a local date within [fromDate, toDate] inclusive, as CSV
+// with a header row: session_id,timestamp_utc,action,category,description,
+// issue_id,billable,label,app_version. Times are RFC 3339 in UTC; app_version
+// is the Timeclock version that wrote the event, empty for events written
+// before versions were recorded. Trashed events are left out.
+func ExportEventsCSV(db *sql.DB, fromDate, toDate string, w io.Writer) error {
+	from, toExclusive, err := localDateBounds(db, fromDate, toDate)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query(`
+SELECT session_id, timestamp_utc, action, category, COALESCE(description, ''),
+       COALESCE(issue_id, ''), billable, COALESCE(label, ''), COALESCE(app_version, '')
+FROM events
+WHERE timestamp_utc >= ? AND timestamp_utc < ? AND deleted_at IS NULL
+ORDER BY timestamp_utc, id;
+`, from.Unix(), toExclusive.Unix())
+	if err != nil {
+		return fmt.Errorf("query events: %w", err)
+	}
+	defer rows.Close()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"session_id", "timestamp_utc", "action", "category", "description", "issue_id", "billable", "label", "app_version"}); err != nil {
+		return err
+	}
+	for rows.Next() {
+		var sessionID, action, category, description, issueID, label, appVersion string
+		var ts int64
+		var billable bool
+		if err := rows.Scan(&sessionID, &ts, &action, &category, &description, &issueID, &billable, &label, &appVersion); err != nil {
+			return err
+		}
+		record := []string{sessionID, time.Unix(ts, 0).UTC().Format(time.RFC3339), action, category, description,
+			issueID, strconv.FormatBool(billable), label, appVersion}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("write event row: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SessionAppVersions returns the distinct Timeclock versions that wrote the
+// session's events, in the order they first appear. Events written before
+// versions were recorded are reported as "unknown".
+func SessionAppVersions(db *sql.DB, sessionID string) ([]string, error) {
+	rows, err := db.Query(`
+SELECT COALESCE(app_version, 'unknown')
+FROM events
+WHERE session_id = ?
+GROUP BY COALESCE(app_version, 'unknown')
+ORDER BY MIN(id);
+`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("query session versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
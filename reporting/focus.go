@@ -0,0 +1,28 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// FocusBreakdown splits the worked time of closed intervals that started on local
+// dates within [fromDate, toDate] inclusive by interval length: intervals lasting
+// at least focusThreshold count as focus time, shorter ones as fragmented time.
+func FocusBreakdown(db *sql.DB, fromDate, toDate string, focusThreshold time.Duration) (focusSeconds, fragmentedSeconds int64, err error) {
+	from, toExclusive, err := localDateBounds(db, fromDate, toDate)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = db.QueryRow(`
+SELECT COALESCE(SUM(CASE WHEN duration_seconds >= ? THEN duration_seconds ELSE 0 END), 0),
+       COALESCE(SUM(CASE WHEN duration_seconds <  ? THEN duration_seconds ELSE 0 END), 0)
+FROM intervals
+WHERE end_utc IS NOT NULL AND start_utc >= ? AND start_utc < ? AND deleted_at IS NULL;
+`, int64(focusThreshold.Seconds()), int64(focusThreshold.Seconds()), from.Unix(), toExclusive.Unix()).Scan(&focusSeconds, &fragmentedSeconds)
+	if err != nil {
+		return 0, 0, fmt.Errorf("query focus breakdown: %w", err)
+	}
+	return focusSeconds, fragmentedSeconds, nil
+}
@@ -0,0 +1,63 @@
+package reporting
+
+import (
+	"fmt"
+	"time"
+)
+
+// DurationFormat selects how FormatDurationWith renders a duration.
+type DurationFormat struct {
+	RoundToMinute bool // "1h 12m" instead of "1h 12m 5s"
+	ShowDays      bool // "2d 3h 12m" for a day or more, instead of "51h 12m"
+}
+
+// FormatDuration renders a duration for display.
+// With roundToMinute it rounds to the nearest minute ("1h 12m", "45m");
+// otherwise seconds are shown ("1h 12m 5s", "45m 3s"). Hours keep counting past
+// 24; use FormatDurationWith to split off days.
+func FormatDuration(d time.Duration, roundToMinute bool) string {
+	return FormatDurationWith(d, DurationFormat{RoundToMinute: roundToMinute})
+}
+
+// FormatDurationWith renders a duration for display as chosen by f. With
+// f.ShowDays, durations of a day or more start with the days ("2d 3h 12m").
+func FormatDurationWith(d time.Duration, f DurationFormat) string {
+	if d < 0 {
+		d = 0
+	}
+	if f.RoundToMinute {
+		mins := int((d + 30*time.Second) / time.Minute)
+		if f.ShowDays && mins >= 24*60 {
+			return fmt.Sprintf("%dd %dh %dm", mins/(24*60), mins%(24*60)/60, mins%60)
+		}
+		if h := mins / 60; h > 0 {
+			return fmt.Sprintf("%dh %dm", h, mins%60)
+		}
+		return fmt.Sprintf("%dm", mins)
+	}
+	days := ""
+	if f.ShowDays && d >= 24*time.Hour {
+		days = fmt.Sprintf("%dd ", int(d/(24*time.Hour)))
+		d %= 24 * time.Hour
+	}
+	h := int(d / time.Hour)
+	m := int((d % time.Hour) / time.Minute)
+	s := int((d % time.Minute) / time.Second)
+	if h > 0 || days != "" {
+		return fmt.Sprintf("%s%dh %dm %ds", days, h, m, s)
+	}
+	return fmt.Sprintf("%dm %ds", m, s)
+}
+
+// TruncateRunes shortens s to at most n characters, marking the cut with "...".
+// Length is counted in runes so multi-byte characters are never split; n below
+// 4 is treated as 4 so there is always room for one character and the marker.
+func TruncateRunes(s string, n int) string {
+	if n < 4 {
+		n = 4
+	}
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-3]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,79 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestFormatDurationWith(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		d    time.Duration
+		f    DurationFormat
+		want string
+	}{
+		{45*time.Minute + 3*time.Second, DurationFormat{}, "45m 3s"},
+		{45*time.Minute + 3*time.Second, DurationFormat{RoundToMinute: true}, "45m"},
+		{-time.Minute, DurationFormat{RoundToMinute: true}, "0m"},
+
+		// Over 24h: hours keep counting unless days are asked for
+		{2*day + 3*time.Hour + 12*time.Minute, DurationFormat{RoundToMinute: true}, "51h 12m"},
+		{2*day + 3*time.Hour + 12*time.Minute, DurationFormat{RoundToMinute: true, ShowDays: true}, "2d 3h 12m"},
+		{day + 5*time.Second, DurationFormat{ShowDays: true}, "1d 0h 0m 5s"},
+		{day + 5*time.Second, DurationFormat{}, "24h 0m 5s"},
+		// Rounding up can carry into the next day
+		{day - 20*time.Second, DurationFormat{RoundToMinute: true, ShowDays: true}, "1d 0h 0m"},
+		// Just under a day has no day part
+		{23*time.Hour + 59*time.Minute, DurationFormat{RoundToMinute: true, ShowDays: true}, "23h 59m"},
+
+		// Over a week: days are not folded into weeks
+		{9*day + 4*time.Hour + 30*time.Minute, DurationFormat{RoundToMinute: true, ShowDays: true}, "9d 4h 30m"},
+		{9*day + 4*time.Hour + 30*time.Minute + 9*time.Second, DurationFormat{ShowDays: true}, "9d 4h 30m 9s"},
+		{9*day + 4*time.Hour + 30*time.Minute, DurationFormat{RoundToMinute: true}, "220h 30m"},
+	}
+	for _, tt := range tests {
+		if got := FormatDurationWith(tt.d, tt.f); got != tt.want {
+			t.Errorf("FormatDurationWith(%v, %+v) = %q, want %q", tt.d, tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDurationNeverShowsDays(t *testing.T) {
+	if got, want := FormatDuration(50*time.Hour+12*time.Minute, true), "50h 12m"; got != want {
+		t.Errorf("FormatDuration = %q, want %q", got, want)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"short", 30, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"exactly ten", 10, "exactly..."},
+		// Accented letters are two bytes each in UTF-8
+		{"Réunion équipe àéîõü", 10, "Réunion..."},
+		{"àéîõüàéîõü", 10, "àéîõüàéîõü"},
+		{"àéîõüàéîõüà", 10, "àéîõüàé..."},
+		// Emoji are four bytes each
+		{"🚀🚀🚀🚀🚀 launch", 8, "🚀🚀🚀🚀🚀..."},
+		{"Deploy 🚀🎉 done", 9, "Deploy..."},
+		{"Deploy 🚀🎉 done", 10, "Deploy ..."},
+		{"Deploy 🚀🎉 done", 11, "Deploy 🚀..."},
+		// Too small a limit still leaves one character and the marker
+		{"abcdef", 2, "a..."},
+		{"", 4, ""},
+	}
+	for _, tt := range tests {
+		got := TruncateRunes(tt.s, tt.n)
+		if got != tt.want {
+			t.Errorf("TruncateRunes(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("TruncateRunes(%q, %d) = %q is not valid UTF-8", tt.s, tt.n, got)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// Gap is a stretch of the workday in which nothing was tracked.
+type Gap struct {
+	StartUTC time.Time
+	EndUTC   time.Time
+}
+
+// Seconds is the length of the gap.
+func (g Gap) Seconds() int64 {
+	return int64(g.EndUTC.Sub(g.StartUTC).Seconds())
+}
+
+// UntrackedGaps returns the gaps in tracking on date dateLocal ('YYYY-MM-DD')
+// within the workday window [workdayStart, workdayEnd) given as 'HH:MM' in the
+// report time zone. Days are those of interval_days (see storage.DayBounds), so
+// with a day boundary of 04:00 a window ending at 02:00 ends on the next
+// calendar date. Gaps before the first and after the last interval count too.
+// An open interval covers time up to now, and the window never extends past now.
+func UntrackedGaps(db *sql.DB, dateLocal string, workdayStart, workdayEnd string) ([]Gap, error) {
+	loc, boundary := storage.ReportLocation(db), storage.DayBoundary(db)
+	dayStart, _, err := storage.DayBounds(dateLocal, loc, boundary)
+	if err != nil {
+		return nil, fmt.Errorf("parse date: %w", err)
+	}
+	// A time of day on the report day: before the boundary, that is the next date
+	at := func(hhmm string) (time.Time, error) {
+		d, err := storage.ParseDayBoundary(hhmm)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), int(d/time.Hour), int(d%time.Hour/time.Minute), 0, 0, loc)
+		if d < boundary {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	windowStart, err := at(workdayStart)
+	if err != nil {
+		return nil, fmt.Errorf("parse workday start: %w", err)
+	}
+	windowEnd, err := at(workdayEnd)
+	if err != nil {
+		return nil, fmt.Errorf("parse workday end: %w", err)
+	}
+	if !windowStart.Before(windowEnd) {
+		return nil, fmt.Errorf("workday start %s is not before end %s", workdayStart, workdayEnd)
+	}
+	now := time.Now()
+	if windowEnd.After(now) {
+		windowEnd = now
+	}
+	if !windowStart.Before(windowEnd) {
+		return nil, nil
+	}
+
+	rows, err := db.Query(`
+SELECT start_utc, COALESCE(end_utc, ?) AS end_utc
+FROM intervals
+WHERE start_utc < ? AND COALESCE(end_utc, ?) > ? AND deleted_at IS NULL
+ORDER BY start_utc;
+`, now.Unix(), windowEnd.Unix(), now.Unix(), windowStart.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query intervals: %w", err)
+	}
+	defer rows.Close()
+
+	// Walk the intervals in start order; anything between the covered-up-to
+	// point and the next start is a gap. Overlaps just extend coverage.
+	var gaps []Gap
+	cursor := windowStart
+	for rows.Next() {
+		var startUnix, endUnix int64
+		if err := rows.Scan(&startUnix, &endUnix); err != nil {
+			return nil, err
+		}
+		start, end := time.Unix(startUnix, 0), time.Unix(endUnix, 0)
+		if start.After(cursor) {
+			gaps = append(gaps, Gap{StartUTC: cursor.UTC(), EndUTC: start.UTC()})
+		}
+		if end.After(cursor) {
+			cursor = end
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if cursor.Before(windowEnd) {
+		gaps = append(gaps, Gap{StartUTC: cursor.UTC(), EndUTC: windowEnd.UTC()})
+	}
+	return gaps, nil
+}
@@ -0,0 +1,46 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// TestUntrackedGapsDayBoundary checks the workday window is placed on the
+// report day: in the report zone, with times before the boundary on the next
+// calendar date.
+func TestUntrackedGapsDayBoundary(t *testing.T) {
+	db, err := storage.OpenAndMigrate(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for key, value := range map[string]string{"report_timezone": "UTC", "day_boundary": "04:00"} {
+		if err := storage.SetSetting(db, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Date(2026, 3, 2, 23, 0, 0, 0, time.UTC)
+	if err := storage.InsertCompletedSession(db, "late", start, start.Add(2*time.Hour), "Dev", "", "test", true); err != nil {
+		t.Fatal(err)
+	}
+
+	// 22:00-03:00 on the 2nd runs into the 3rd; worked 23:00-01:00
+	gaps, err := UntrackedGaps(db, "2026-03-02", "22:00", "03:00")
+	if err != nil {
+		t.Fatalf("UntrackedGaps: %v", err)
+	}
+	want := []Gap{
+		{time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC), start},
+		{start.Add(2 * time.Hour), time.Date(2026, 3, 3, 3, 0, 0, 0, time.UTC)},
+	}
+	if len(gaps) != len(want) {
+		t.Fatalf("gaps = %v, want %v", gaps, want)
+	}
+	for i := range want {
+		if !gaps[i].StartUTC.Equal(want[i].StartUTC) || !gaps[i].EndUTC.Equal(want[i].EndUTC) {
+			t.Errorf("gap %d = %v-%v, want %v-%v", i, gaps[i].StartUTC, gaps[i].EndUTC, want[i].StartUTC, want[i].EndUTC)
+		}
+	}
+}
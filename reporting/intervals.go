@@ -0,0 +1,67 @@
+package reporting
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportIntervalsCSV writes the raw intervals (not sliced into days) whose start
+// falls on a local date within [fromDate, toDate] inclusive, as CSV with a
+// header row: session_id,interval_index,start_utc,end_utc,duration_seconds,category,
+// description,issue_id,billable,label. Times are RFC 3339 in UTC and billable is
+// true or false. Open intervals are included with empty end_utc and
+// duration_seconds. Rows are streamed to w as they are read.
+func ExportIntervalsCSV(db *sql.DB, fromDate, toDate string, w io.Writer) error {
+	from, toExclusive, err := localDateBounds(db, fromDate, toDate)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query(`
+SELECT session_id, interval_index, start_utc, end_utc, duration_seconds, category, COALESCE(description, ''),
+       COALESCE(issue_id, ''), billable, COALESCE(label, '')
+FROM intervals
+WHERE start_utc >= ? AND start_utc < ? AND deleted_at IS NULL
+ORDER BY start_utc, id;
+`, from.Unix(), toExclusive.Unix())
+	if err != nil {
+		return fmt.Errorf("query intervals: %w", err)
+	}
+	defer rows.Close()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"session_id", "interval_index", "start_utc", "end_utc", "duration_seconds", "category", "description", "issue_id", "billable", "label"}); err != nil {
+		return err
+	}
+	for rows.Next() {
+		var sessionID, category, description, issueID, label string
+		var index int
+		var startUTC int64
+		var endUTC, durationSeconds sql.NullInt64
+		var billable bool
+		if err := rows.Scan(&sessionID, &index, &startUTC, &endUTC, &durationSeconds, &category, &description, &issueID, &billable, &label); err != nil {
+			return err
+		}
+		end, duration := "", ""
+		if endUTC.Valid {
+			end = time.Unix(endUTC.Int64, 0).UTC().Format(time.RFC3339)
+		}
+		if durationSeconds.Valid {
+			duration = strconv.FormatInt(durationSeconds.Int64, 10)
+		}
+		record := []string{sessionID, strconv.Itoa(index), time.Unix(startUTC, 0).UTC().Format(time.RFC3339), end, duration, category, description,
+			issueID, strconv.FormatBool(billable), label}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("write interval row: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
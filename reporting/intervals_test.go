@@ -0,0 +1,124 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// TestCSVRoundTripSpecialCharacters imports a description and category that
+// need CSV quoting, exports them again and checks nothing was lost on the way.
+func TestCSVRoundTripSpecialCharacters(t *testing.T) {
+	db, err := storage.OpenAndMigrate(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	const (
+		category    = `R&D, "internal"`
+		description = "Fix parser, part 2\nSaid \"done\", then wasn't"
+	)
+
+	// Build the import file with encoding/csv so it is quoted like a real export
+	var in bytes.Buffer
+	cw := csv.NewWriter(&in)
+	cw.WriteAll([][]string{
+		{"User", "Project", "Description", "Billable", "Start date", "Start time", "Duration"},
+		{"me", category, description, "No", "2026-03-02", "09:00:00", "01:30:00"},
+	})
+	if err := cw.Error(); err != nil {
+		t.Fatal(err)
+	}
+	imported, skipped, _, err := storage.ImportTogglCSV(db, &in, "1.2.3", true, false)
+	if err != nil || imported != 1 || skipped != 0 {
+		t.Fatalf("ImportTogglCSV = %d imported, %d skipped, %v; want 1, 0, nil", imported, skipped, err)
+	}
+
+	var out bytes.Buffer
+	if err := ExportIntervalsCSV(db, "2026-03-02", "2026-03-02", &out); err != nil {
+		t.Fatalf("ExportIntervalsCSV: %v", err)
+	}
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("exported CSV does not parse: %v\n%s", err, out.String())
+	}
+	if len(records) != 2 {
+		t.Fatalf("exported %d records, want a header and 1 row:\n%s", len(records), out.String())
+	}
+	row := map[string]string{}
+	for i, name := range records[0] {
+		row[name] = records[1][i]
+	}
+	want := map[string]string{
+		"category":         category,
+		"description":      description,
+		"duration_seconds": "5400",
+	}
+	for column, value := range want {
+		if row[column] != value {
+			t.Errorf("%s = %q, want %q", column, row[column], value)
+		}
+	}
+
+	// The JSON report carries the category through encoding/json
+	var js bytes.Buffer
+	if err := ExportJSON(db, "2026-03-02", "2026-03-02", &js); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+	var report JSONReport
+	if err := json.Unmarshal(js.Bytes(), &report); err != nil {
+		t.Fatalf("exported JSON does not parse: %v", err)
+	}
+	if len(report.Categories) != 1 || report.Categories[0].Category != category {
+		t.Errorf("JSON categories = %+v, want one line for %q", report.Categories, category)
+	}
+}
+
+// TestExportEventsCSVAppVersion checks each exported event names the version
+// that wrote it, and that the session lists that version.
+func TestExportEventsCSVAppVersion(t *testing.T) {
+	db, err := storage.OpenAndMigrate(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local).UTC()
+	if err := storage.InsertCompletedSession(db, "s1", start, start.Add(time.Hour), "Dev", "", "1.2.3", true); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := ExportEventsCSV(db, "2026-03-02", "2026-03-02", &out); err != nil {
+		t.Fatalf("ExportEventsCSV: %v", err)
+	}
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("exported CSV does not parse: %v\n%s", err, out.String())
+	}
+	if len(records) != 3 {
+		t.Fatalf("exported %d records, want a header and START and STOP:\n%s", len(records), out.String())
+	}
+	last := len(records[0]) - 1
+	if records[0][last] != "app_version" {
+		t.Fatalf("last column = %q, want app_version", records[0][last])
+	}
+	for _, r := range records[1:] {
+		if r[last] != "1.2.3" {
+			t.Errorf("%s app_version = %q, want %q", r[2], r[last], "1.2.3")
+		}
+	}
+
+	versions, err := SessionAppVersions(db, "s1")
+	if err != nil {
+		t.Fatalf("SessionAppVersions: %v", err)
+	}
+	if len(versions) != 1 || versions[0] != "1.2.3" {
+		t.Errorf("SessionAppVersions = %q, want [1.2.3]", versions)
+	}
+}
@@ -0,0 +1,39 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// IssueTotal is the total duration recorded against one ticket/issue id.
+type IssueTotal struct {
+	IssueID      string
+	TotalSeconds int64
+}
+
+// TotalsByIssue returns duration_seconds summed per issue id for local dates within
+// [fromDate, toDate] inclusive, largest first. Work without an issue id is omitted.
+func TotalsByIssue(db *sql.DB, fromDate, toDate string) ([]IssueTotal, error) {
+	rows, err := db.Query(`
+SELECT i.issue_id, SUM(d.duration_seconds) AS total_seconds
+FROM interval_days d
+JOIN intervals i ON i.id = d.interval_id
+WHERE d.date_local >= ? AND d.date_local <= ? AND i.issue_id IS NOT NULL AND d.deleted_at IS NULL
+GROUP BY i.issue_id
+ORDER BY total_seconds DESC, i.issue_id;
+`, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("query issue totals: %w", err)
+	}
+	defer rows.Close()
+
+	var res []IssueTotal
+	for rows.Next() {
+		var t IssueTotal
+		if err := rows.Scan(&t.IssueID, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,91 @@
+package reporting
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+)
+
+// JSONReport is the document written by ExportJSON.
+type JSONReport struct {
+	From            string             `json:"from"`
+	To              string             `json:"to"`
+	TotalSeconds    int64              `json:"total_seconds"`
+	BillableSeconds int64              `json:"billable_seconds"`
+	Categories      []JSONCategoryLine `json:"categories"`
+	Issues          []JSONIssueLine    `json:"issues"`
+	Labels          []JSONLabelLine    `json:"labels"`
+	DaysWorked      []string           `json:"days_worked"`
+}
+
+// JSONCategoryLine is one category total in a JSONReport.
+type JSONCategoryLine struct {
+	Category     string `json:"category"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
+
+// JSONIssueLine is the time recorded against one issue id in a JSONReport.
+type JSONIssueLine struct {
+	IssueID      string `json:"issue_id"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
+
+// JSONLabelLine is the time recorded under one session label in a JSONReport.
+type JSONLabelLine struct {
+	Label        string `json:"label"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
+
+// ExportJSON writes the category, issue and label totals, billable time and
+// presence days for local dates within [fromDate, toDate] inclusive as an
+// indented JSON document.
+// Durations are raw seconds so other tools can do their own rounding.
+func ExportJSON(db *sql.DB, fromDate, toDate string, w io.Writer) error {
+	totals, err := TotalsByCategory(db, fromDate, toDate, nil)
+	if err != nil {
+		return err
+	}
+	days, err := PresenceDays(db, fromDate, toDate, nil)
+	if err != nil {
+		return err
+	}
+	billable, _, err := BillableSummary(db, fromDate, toDate)
+	if err != nil {
+		return err
+	}
+	issues, err := TotalsByIssue(db, fromDate, toDate)
+	if err != nil {
+		return err
+	}
+	labels, err := TotalsByLabel(db, fromDate, toDate)
+	if err != nil {
+		return err
+	}
+
+	report := JSONReport{
+		From:            fromDate,
+		To:              toDate,
+		BillableSeconds: billable,
+		Categories:      []JSONCategoryLine{},
+		Issues:          []JSONIssueLine{},
+		Labels:          []JSONLabelLine{},
+		DaysWorked:      days,
+	}
+	if report.DaysWorked == nil {
+		report.DaysWorked = []string{}
+	}
+	for _, t := range totals {
+		report.Categories = append(report.Categories, JSONCategoryLine{Category: t.Category, TotalSeconds: t.TotalSeconds})
+		report.TotalSeconds += t.TotalSeconds
+	}
+	for _, t := range issues {
+		report.Issues = append(report.Issues, JSONIssueLine{IssueID: t.IssueID, TotalSeconds: t.TotalSeconds})
+	}
+	for _, t := range labels {
+		report.Labels = append(report.Labels, JSONLabelLine{Label: t.Label, TotalSeconds: t.TotalSeconds})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
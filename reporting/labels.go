@@ -0,0 +1,39 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// LabelTotal is the total duration recorded under one session label.
+type LabelTotal struct {
+	Label        string
+	TotalSeconds int64
+}
+
+// TotalsByLabel returns duration_seconds summed per session label for local dates
+// within [fromDate, toDate] inclusive, largest first. Unlabelled work is omitted.
+func TotalsByLabel(db *sql.DB, fromDate, toDate string) ([]LabelTotal, error) {
+	rows, err := db.Query(`
+SELECT i.label, SUM(d.duration_seconds) AS total_seconds
+FROM interval_days d
+JOIN intervals i ON i.id = d.interval_id
+WHERE d.date_local >= ? AND d.date_local <= ? AND i.label IS NOT NULL AND d.deleted_at IS NULL
+GROUP BY i.label
+ORDER BY total_seconds DESC, i.label;
+`, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("query label totals: %w", err)
+	}
+	defer rows.Close()
+
+	var res []LabelTotal
+	for rows.Next() {
+		var t LabelTotal
+		if err := rows.Scan(&t.Label, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,32 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// LifetimeTotal returns the duration_seconds of all work ever recorded, leaving
+// out the trash.
+func LifetimeTotal(db *sql.DB) (int64, error) {
+	var total int64
+	if err := db.QueryRow(`
+SELECT COALESCE(SUM(duration_seconds), 0) FROM interval_days WHERE deleted_at IS NULL;
+`).Scan(&total); err != nil {
+		return 0, fmt.Errorf("query lifetime total: %w", err)
+	}
+	return total, nil
+}
+
+// FirstEventTime returns when the earliest event still on record happened.
+// ok is false when nothing has been recorded yet.
+func FirstEventTime(db *sql.DB) (first time.Time, ok bool, err error) {
+	var ts sql.NullInt64
+	if err := db.QueryRow(`SELECT MIN(timestamp_utc) FROM events WHERE deleted_at IS NULL;`).Scan(&ts); err != nil {
+		return time.Time{}, false, fmt.Errorf("query first event: %w", err)
+	}
+	if !ts.Valid {
+		return time.Time{}, false, nil
+	}
+	return time.Unix(ts.Int64, 0).UTC(), true, nil
+}
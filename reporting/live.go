@@ -0,0 +1,54 @@
+package reporting
+
+import (
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/1kaius1/Timeclock/domain"
+)
+
+// TotalsByCategoryLive is TotalsByCategory plus the interval currently running in
+// state. TotalsByCategory only sees closed intervals, because interval_days is
+// written when an interval closes; this adds the part of the open interval's
+// elapsed time that falls within [fromDate, toDate] (local dates) to its category.
+// Use it for "so far today" figures; use TotalsByCategory for settled totals.
+func TotalsByCategoryLive(db *sql.DB, state *domain.AppState, fromDate, toDate string) ([]CategoryTotal, error) {
+	totals, err := TotalsByCategory(db, fromDate, toDate, nil)
+	if err != nil {
+		return nil, err
+	}
+	open, ok := state.CurrentInterval()
+	if !ok {
+		return totals, nil
+	}
+
+	from, toExclusive, err := localDateBounds(db, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	start, end := open.StartUTC, open.StartUTC.Add(open.Elapsed)
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(toExclusive) {
+		end = toExclusive
+	}
+	if !start.Before(end) {
+		return totals, nil
+	}
+	running := int64(end.Sub(start) / time.Second)
+
+	found := false
+	for i := range totals {
+		if totals[i].Category == open.Category {
+			totals[i].TotalSeconds += running
+			found = true
+		}
+	}
+	if !found {
+		totals = append(totals, CategoryTotal{Category: open.Category, TotalSeconds: running})
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].TotalSeconds > totals[j].TotalSeconds })
+	return totals, nil
+}
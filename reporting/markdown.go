@@ -0,0 +1,63 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExportMarkdown writes a GitHub-flavored Markdown summary for local dates within
+// [fromDate, toDate] inclusive: a heading with the range, a table of category
+// totals, the grand total with its billable share, and the presence days.
+// Durations are rounded to the nearest minute, which suits standup notes.
+func ExportMarkdown(db *sql.DB, fromDate, toDate string, w io.Writer) error {
+	totals, err := TotalsByCategory(db, fromDate, toDate, nil)
+	if err != nil {
+		return err
+	}
+	days, err := PresenceDays(db, fromDate, toDate, nil)
+	if err != nil {
+		return err
+	}
+	billable, _, err := BillableSummary(db, fromDate, toDate)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Timeclock report: %s to %s\n\n", fromDate, toDate)
+
+	var grandTotal int64
+	if len(totals) == 0 {
+		b.WriteString("_No time recorded in this range._\n\n")
+	} else {
+		b.WriteString("| Category | Total |\n")
+		b.WriteString("| --- | ---: |\n")
+		for _, t := range totals {
+			fmt.Fprintf(&b, "| %s | %s |\n", escapeMarkdownCell(t.Category), FormatDuration(time.Duration(t.TotalSeconds)*time.Second, true))
+			grandTotal += t.TotalSeconds
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "**Total:** %s (billable %s)\n\n", FormatDuration(time.Duration(grandTotal)*time.Second, true),
+		FormatDuration(time.Duration(billable)*time.Second, true))
+
+	if len(days) == 0 {
+		b.WriteString("**Days with any work:** none\n")
+	} else {
+		fmt.Fprintf(&b, "**Days with any work (%d):** %s\n", len(days), strings.Join(days, ", "))
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// escapeMarkdownCell keeps user text from breaking the table layout.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
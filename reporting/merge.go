@@ -0,0 +1,79 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TotalsByCategoryMerged is TotalsByCategory with short breaks treated as work:
+// consecutive closed intervals of the same session and category separated by a
+// break shorter than maxGap are coalesced, so the break's seconds are added to
+// that category. Stored rows are not modified.
+//
+// A break is attributed to the local date on which it began, and is counted when
+// that date falls within [fromDate, toDate].
+func TotalsByCategoryMerged(db *sql.DB, fromDate, toDate string, exclude []string, maxGap time.Duration) ([]CategoryTotal, error) {
+	totals, err := TotalsByCategory(db, fromDate, toDate, exclude)
+	if err != nil {
+		return nil, err
+	}
+	if maxGap <= 0 {
+		return totals, nil
+	}
+
+	from, toExclusive, err := localDateBounds(db, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeSQL, excludeArgs := excludeCategoriesClause("a.category", exclude)
+	args := append([]any{from.Unix(), toExclusive.Unix(), int64(maxGap.Seconds())}, excludeArgs...)
+
+	// Pair each closed interval with the next interval of its session. Split
+	// intervals are inserted out of id order, so go by interval_index.
+	rows, err := db.Query(`
+SELECT a.category, SUM(b.start_utc - a.end_utc)
+FROM intervals a
+JOIN intervals b
+  ON b.session_id = a.session_id AND b.interval_index = a.interval_index + 1
+WHERE a.end_utc IS NOT NULL AND b.end_utc IS NOT NULL AND a.deleted_at IS NULL AND b.deleted_at IS NULL
+  AND a.category = b.category
+  AND a.end_utc >= ? AND a.end_utc < ?
+  AND b.start_utc >= a.end_utc
+  AND b.start_utc - a.end_utc < ?`+excludeSQL+`
+GROUP BY a.category;
+`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query mergeable breaks: %w", err)
+	}
+	defer rows.Close()
+
+	gaps := map[string]int64{}
+	for rows.Next() {
+		var category string
+		var seconds int64
+		if err := rows.Scan(&category, &seconds); err != nil {
+			return nil, err
+		}
+		gaps[category] = seconds
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range totals {
+		totals[i].TotalSeconds += gaps[totals[i].Category]
+		delete(gaps, totals[i].Category)
+	}
+	// Categories whose only time in range is a merged break (rare, but possible
+	// when the work itself was sliced onto a neighbouring day).
+	for category, seconds := range gaps {
+		if seconds > 0 {
+			totals = append(totals, CategoryTotal{Category: category, TotalSeconds: seconds})
+		}
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].TotalSeconds > totals[j].TotalSeconds })
+	return totals, nil
+}
@@ -0,0 +1,47 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// TestTotalsByCategoryMergedAcrossSplit merges the break after an interval
+// that was split, whose second half has a higher id than the interval after it.
+func TestTotalsByCategoryMergedAcrossSplit(t *testing.T) {
+	db, err := storage.OpenAndMigrate(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// 09:00-10:00 and, after a 5 minute break, 10:05-11:00
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	at := func(h, m int) time.Time {
+		return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute).UTC()
+	}
+	for i, iv := range [][2]time.Time{{at(9, 0), at(10, 0)}, {at(10, 5), at(11, 0)}} {
+		if err := storage.OpenInterval(db, "s1", i, iv[0], "Dev", "", "", "", true); err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.CloseOpenIntervalAndSliceDays(db, "s1", iv[0], iv[1], "Dev", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var firstID int64
+	if err := db.QueryRow(`SELECT id FROM intervals WHERE session_id = 's1' AND interval_index = 0;`).Scan(&firstID); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SplitInterval(db, firstID, at(9, 30), "Dev", "Dev"); err != nil {
+		t.Fatalf("SplitInterval: %v", err)
+	}
+
+	totals, err := TotalsByCategoryMerged(db, "2026-03-02", "2026-03-02", nil, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("TotalsByCategoryMerged: %v", err)
+	}
+	if len(totals) != 1 || totals[0].TotalSeconds != 2*3600 {
+		t.Errorf("merged totals = %+v, want Dev for 2h including the break", totals)
+	}
+}
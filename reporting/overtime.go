@@ -0,0 +1,32 @@
+package reporting
+
+import "database/sql"
+
+// DayOvertime splits one local date's worked time at a daily threshold.
+type DayOvertime struct {
+	Date            string // 'YYYY-MM-DD'
+	RegularSeconds  int64  // up to the threshold
+	OvertimeSeconds int64  // beyond the threshold
+	TotalSeconds    int64
+}
+
+// OvertimeByDay returns, for each local date within [fromDate, toDate] inclusive
+// that has work, the time up to regularSeconds as regular and the rest as
+// overtime, ordered by date. Days without work are omitted.
+func OvertimeByDay(db *sql.DB, fromDate, toDate string, regularSeconds int64) ([]DayOvertime, error) {
+	days, err := TotalsByDay(db, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]DayOvertime, 0, len(days))
+	for _, d := range days {
+		regular := min(d.TotalSeconds, regularSeconds)
+		res = append(res, DayOvertime{
+			Date:            d.Date,
+			RegularSeconds:  regular,
+			OvertimeSeconds: d.TotalSeconds - regular,
+			TotalSeconds:    d.TotalSeconds,
+		})
+	}
+	return res, nil
+}
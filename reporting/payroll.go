@@ -0,0 +1,86 @@
+package reporting
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ExportPayrollCSV writes one row per local date and category within
+// [fromDate, toDate] inclusive in the payroll format "date,hours_decimal,project_code",
+// with a header row. mapping translates categories to project codes; a category
+// without a code is written under its own name (see UnmappedPayrollCategories).
+// Hours are decimal with two places, e.g. 1.25. With billableOnly, time from
+// non-billable sessions is left out.
+func ExportPayrollCSV(db *sql.DB, fromDate, toDate string, mapping map[string]string, billableOnly bool, w io.Writer) error {
+	totals, err := TotalsByDayAndCategory(db, fromDate, toDate)
+	if billableOnly {
+		totals, err = billableTotalsByDayAndCategory(db, fromDate, toDate)
+	}
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "hours_decimal", "project_code"}); err != nil {
+		return err
+	}
+	for _, t := range totals {
+		code := mapping[t.Category]
+		if code == "" {
+			code = t.Category
+		}
+		hours := strconv.FormatFloat(float64(t.TotalSeconds)/3600, 'f', 2, 64)
+		if err := cw.Write([]string{t.Date, hours, code}); err != nil {
+			return fmt.Errorf("write payroll row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// billableTotalsByDayAndCategory is TotalsByDayAndCategory restricted to
+// billable sessions.
+func billableTotalsByDayAndCategory(db *sql.DB, fromDate, toDate string) ([]DayCategoryTotal, error) {
+	rows, err := db.Query(`
+SELECT d.date_local, d.category, SUM(d.duration_seconds) AS total_seconds
+FROM interval_days d
+JOIN intervals i ON i.id = d.interval_id
+WHERE d.date_local >= ? AND d.date_local <= ? AND d.deleted_at IS NULL AND i.billable
+GROUP BY d.date_local, d.category
+ORDER BY d.date_local, d.category;
+`, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("query billable daily totals: %w", err)
+	}
+	defer rows.Close()
+
+	var res []DayCategoryTotal
+	for rows.Next() {
+		var t DayCategoryTotal
+		if err := rows.Scan(&t.Date, &t.Category, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
+
+// UnmappedPayrollCategories returns the categories with time in [fromDate, toDate]
+// that have no project code in mapping, in descending order of time, so callers
+// can flag rows ExportPayrollCSV wrote under the category name.
+func UnmappedPayrollCategories(db *sql.DB, fromDate, toDate string, mapping map[string]string) ([]string, error) {
+	totals, err := TotalsByCategory(db, fromDate, toDate, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, t := range totals {
+		if mapping[t.Category] == "" {
+			res = append(res, t.Category)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PresenceDaysInZone is PresenceDays computed from the intervals' instants in loc,
+// ignoring the date labels stored in interval_days. The result does not depend on
+// the zone the machine was in when each interval was recorded, at the cost of
+// walking every interval in the range. fromDate and toDate are dates in loc.
+func PresenceDaysInZone(db *sql.DB, fromDate, toDate string, loc *time.Location) ([]string, error) {
+	from, err := time.ParseInLocation("2006-01-02", fromDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("parse from date: %w", err)
+	}
+	to, err := time.ParseInLocation("2006-01-02", toDate, loc)
+	if err != nil {
+		return nil, fmt.Errorf("parse to date: %w", err)
+	}
+	toExclusive := to.AddDate(0, 0, 1)
+
+	rows, err := db.Query(`
+SELECT start_utc, end_utc
+FROM intervals
+WHERE end_utc IS NOT NULL AND end_utc > start_utc AND start_utc < ? AND end_utc > ? AND deleted_at IS NULL;
+`, toExclusive.Unix(), from.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query intervals: %w", err)
+	}
+	defer rows.Close()
+
+	seen := map[string]bool{}
+	for rows.Next() {
+		var startUnix, endUnix int64
+		if err := rows.Scan(&startUnix, &endUnix); err != nil {
+			return nil, err
+		}
+		start := time.Unix(startUnix, 0).In(loc)
+		end := time.Unix(endUnix, 0).In(loc)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(toExclusive) {
+			end = toExclusive
+		}
+		// Every day the interval touches, end exclusive
+		for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); day.Before(end); day = day.AddDate(0, 0, 1) {
+			seen[day.Format("2006-01-02")] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	days := make([]string, 0, len(seen))
+	for d := range seen {
+		days = append(days, d)
+	}
+	sort.Strings(days)
+	return days, nil
+}
@@ -0,0 +1,85 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WeeklyRecap returns a plain-text summary of the Monday-to-Sunday week containing
+// weekStartDate ('YYYY-MM-DD'): total time, per-category breakdown with
+// percentages, days worked, the longest session, and the change from the prior
+// week. Durations are rounded to the nearest minute.
+func WeeklyRecap(db *sql.DB, weekStartDate string) (string, error) {
+	date, err := time.Parse("2006-01-02", weekStartDate)
+	if err != nil {
+		return "", fmt.Errorf("parse date %q: %w", weekStartDate, err)
+	}
+	start := weekStart(date)
+	from := start.Format("2006-01-02")
+	to := start.AddDate(0, 0, 6).Format("2006-01-02")
+	prevFrom := start.AddDate(0, 0, -7).Format("2006-01-02")
+	prevTo := start.AddDate(0, 0, -1).Format("2006-01-02")
+
+	totals, err := TotalsByCategory(db, from, to, nil)
+	if err != nil {
+		return "", err
+	}
+	days, err := PresenceDays(db, from, to, nil)
+	if err != nil {
+		return "", err
+	}
+	longest, _, err := SessionExtremes(db, from, to)
+	if err != nil {
+		return "", err
+	}
+	prevTotals, err := TotalsByCategory(db, prevFrom, prevTo, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var total, prevTotal int64
+	for _, t := range totals {
+		total += t.TotalSeconds
+	}
+	for _, t := range prevTotals {
+		prevTotal += t.TotalSeconds
+	}
+	dur := func(secs int64) string {
+		return FormatDuration(time.Duration(secs)*time.Second, true)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Weekly recap: %s to %s\n\n", from, to)
+	fmt.Fprintf(&b, "Total: %s\n", dur(total))
+	fmt.Fprintf(&b, "Days worked: %d of 7\n\n", len(days))
+
+	if len(totals) == 0 {
+		b.WriteString("No time recorded this week.\n")
+	} else {
+		b.WriteString("By category:\n")
+		for _, t := range totals {
+			pct := 0.0
+			if total > 0 {
+				pct = float64(t.TotalSeconds) * 100 / float64(total)
+			}
+			fmt.Fprintf(&b, "  %s: %s (%.0f%%)\n", t.Category, dur(t.TotalSeconds), pct)
+		}
+	}
+
+	if longest.SessionID != "" {
+		fmt.Fprintf(&b, "\nLongest session: %s on %s\n", dur(longest.TotalSeconds), longest.StartUTC.Local().Format("Mon 2006-01-02"))
+	}
+
+	b.WriteString("\n")
+	switch diff := total - prevTotal; {
+	case prevTotal == 0:
+		b.WriteString("Prior week: no time recorded.\n")
+	case diff >= 0:
+		fmt.Fprintf(&b, "Prior week: %s (%s more, %+.0f%%)\n", dur(prevTotal), dur(diff), float64(diff)*100/float64(prevTotal))
+	default:
+		fmt.Fprintf(&b, "Prior week: %s (%s less, %+.0f%%)\n", dur(prevTotal), dur(-diff), float64(diff)*100/float64(prevTotal))
+	}
+	return b.String(), nil
+}
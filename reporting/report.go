@@ -4,24 +4,29 @@ package reporting
 import (
     "database/sql"
     "fmt"
+    "strings"
 )
 
 // TotalsByCategory returns duration_seconds summed per category for local dates within [fromDate, toDate] inclusive.
 // fromDate/toDate format: "YYYY-MM-DD"
+// Categories listed in exclude are omitted from the results.
+// Only closed intervals are counted; TotalsByCategoryLive adds the running one.
 type CategoryTotal struct {
     Category       string
     TotalSeconds   int64
     FormattedHuman string // optional formatting done by caller; we return raw seconds
 }
 
-func TotalsByCategory(db *sql.DB, fromDate, toDate string) ([]CategoryTotal, error) {
+func TotalsByCategory(db *sql.DB, fromDate, toDate string, exclude []string) ([]CategoryTotal, error) {
+    excludeSQL, excludeArgs := excludeCategoriesClause("category", exclude)
+    args := append([]any{fromDate, toDate}, excludeArgs...)
     rows, err := db.Query(`
 SELECT category, SUM(duration_seconds) AS total_seconds
 FROM interval_days
-WHERE date_local >= ? AND date_local <= ?
+WHERE date_local >= ? AND date_local <= ? AND deleted_at IS NULL`+excludeSQL+`
 GROUP BY category
 ORDER BY total_seconds DESC;
-`, fromDate, toDate)
+`, args...)
     if err != nil {
         return nil, fmt.Errorf("query totals: %w", err)
     }
@@ -39,13 +44,16 @@ ORDER BY total_seconds DESC;
 }
 
 // PresenceDays returns a sorted list of distinct local dates where any work occurred (duration_seconds > 0).
-func PresenceDays(db *sql.DB, fromDate, toDate string) ([]string, error) {
+// Work in categories listed in exclude does not count as presence.
+func PresenceDays(db *sql.DB, fromDate, toDate string, exclude []string) ([]string, error) {
+    excludeSQL, excludeArgs := excludeCategoriesClause("category", exclude)
+    args := append([]any{fromDate, toDate}, excludeArgs...)
     rows, err := db.Query(`
 SELECT DISTINCT date_local
 FROM interval_days
-WHERE date_local >= ? AND date_local <= ? AND duration_seconds > 0
+WHERE date_local >= ? AND date_local <= ? AND duration_seconds > 0 AND deleted_at IS NULL`+excludeSQL+`
 ORDER BY date_local;
-`, fromDate, toDate)
+`, args...)
     if err != nil {
         return nil, fmt.Errorf("query presence days: %w", err)
     }
@@ -62,4 +70,18 @@ ORDER BY date_local;
     return days, rows.Err()
 }
 
+// excludeCategoriesClause builds an "AND <column> NOT IN (...)" fragment and its
+// arguments. It returns an empty fragment when there is nothing to exclude.
+func excludeCategoriesClause(column string, exclude []string) (string, []any) {
+    if len(exclude) == 0 {
+        return "", nil
+    }
+    placeholders := make([]string, len(exclude))
+    args := make([]any, len(exclude))
+    for i, c := range exclude {
+        placeholders[i] = "?"
+        args[i] = c
+    }
+    return " AND " + column + " NOT IN (" + strings.Join(placeholders, ", ") + ")", args
+}
 
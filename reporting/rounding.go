@@ -0,0 +1,80 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DayRounding compares one day's exact total with its minute-rounded total.
+type DayRounding struct {
+	Date           string
+	ExactSeconds   int64
+	RoundedSeconds int64
+}
+
+// RoundingReconciliation shows why rounded daily totals need not add up to the
+// rounded range total: rounding the summed seconds once (SumThenRound) and
+// adding up the individually rounded days (RoundThenSum) can differ.
+type RoundingReconciliation struct {
+	Days                []DayRounding
+	ExactSeconds        int64
+	SumThenRoundSeconds int64
+	RoundThenSumSeconds int64
+}
+
+// ReconcileRounding computes exact and minute-rounded totals per local date within
+// [fromDate, toDate] inclusive, and the range total rounded both ways.
+// Rounding is to the nearest minute, matching the display preference.
+func ReconcileRounding(db *sql.DB, fromDate, toDate string) (RoundingReconciliation, error) {
+	days, err := TotalsByDay(db, fromDate, toDate)
+	if err != nil {
+		return RoundingReconciliation{}, err
+	}
+
+	var rec RoundingReconciliation
+	for _, d := range days {
+		rounded := roundSecondsToMinute(d.TotalSeconds)
+		rec.Days = append(rec.Days, DayRounding{Date: d.Date, ExactSeconds: d.TotalSeconds, RoundedSeconds: rounded})
+		rec.ExactSeconds += d.TotalSeconds
+		rec.RoundThenSumSeconds += rounded
+	}
+	rec.SumThenRoundSeconds = roundSecondsToMinute(rec.ExactSeconds)
+	return rec, nil
+}
+
+// roundSecondsToMinute rounds to the nearest whole minute, half up.
+func roundSecondsToMinute(secs int64) int64 {
+	return (secs + 30) / 60 * 60
+}
+
+// CategoryRounding is one category's exact total and that total rounded up.
+type CategoryRounding struct {
+	Category       string
+	ExactSeconds   int64
+	RoundedSeconds int64
+}
+
+// TotalsByCategoryRounded sums the raw seconds per category for local dates within
+// [fromDate, toDate] inclusive (see TotalsByCategory), then rounds each category's
+// total up to the next multiple of roundTo, e.g. a quarter hour for billing.
+// Only the totals are rounded; stored intervals are left alone.
+func TotalsByCategoryRounded(db *sql.DB, fromDate, toDate string, roundTo time.Duration) ([]CategoryRounding, error) {
+	step := int64(roundTo / time.Second)
+	if step <= 0 {
+		return nil, fmt.Errorf("rounding step must be at least a second, got %s", roundTo)
+	}
+	totals, err := TotalsByCategory(db, fromDate, toDate, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]CategoryRounding, 0, len(totals))
+	for _, t := range totals {
+		res = append(res, CategoryRounding{
+			Category:       t.Category,
+			ExactSeconds:   t.TotalSeconds,
+			RoundedSeconds: (t.TotalSeconds + step - 1) / step * step,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,59 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ScheduledExportFormats are the formats a weekly export can be written in.
+var ScheduledExportFormats = []string{"CSV", "JSON", "Markdown"}
+
+// scheduledExportExtensions maps ScheduledExportFormats to file extensions.
+var scheduledExportExtensions = map[string]string{"CSV": ".csv", "JSON": ".json", "Markdown": ".md"}
+
+// PreviousWeek returns the Monday-to-Sunday week before the one containing now,
+// as its ISO week name (e.g. "2024-W07") and its first and last local dates.
+func PreviousWeek(now time.Time) (week, fromDate, toDate string) {
+	start := weekStart(now).AddDate(0, 0, -7)
+	year, num := start.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, num), start.Format("2006-01-02"), start.AddDate(0, 0, 6).Format("2006-01-02")
+}
+
+// WriteWeeklyExport writes the report for the ISO week named week, covering
+// [fromDate, toDate], to dir as timeclock-<week> with the extension of format.
+// CSV is the raw intervals (ExportIntervalsCSV); JSON and Markdown are the
+// summaries of ExportJSON and ExportMarkdown. It returns the file's path. A
+// failed export leaves no file behind.
+func WriteWeeklyExport(db *sql.DB, dir, format, week, fromDate, toDate string) (string, error) {
+	var export func(*sql.DB, string, string, io.Writer) error
+	switch format {
+	case "CSV":
+		export = ExportIntervalsCSV
+	case "JSON":
+		export = ExportJSON
+	case "Markdown":
+		export = ExportMarkdown
+	default:
+		return "", fmt.Errorf("unknown export format %q", format)
+	}
+
+	path := filepath.Join(dir, "timeclock-"+week+scheduledExportExtensions[format])
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create export file: %w", err)
+	}
+	if err := export(db, fromDate, toDate, f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("write export file: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,149 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// SessionSummary aggregates the intervals of one completed session.
+// A zero SessionSummary (empty SessionID) stands for "no session".
+type SessionSummary struct {
+	SessionID    string
+	Label        string    // the session's label, "" if it has none
+	StartUTC     time.Time // start of the first interval
+	EndUTC       time.Time // end of the last interval
+	Intervals    int
+	TotalSeconds int64 // worked time, excluding breaks
+	SpanSeconds  int64 // wall-clock time from the first event to the last
+}
+
+// Name is the session's label, or its id when it has no label.
+func (s SessionSummary) Name() string {
+	if s.Label != "" {
+		return s.Label
+	}
+	return s.SessionID
+}
+
+// BreakSeconds is the part of the span that was not worked.
+func (s SessionSummary) BreakSeconds() int64 {
+	return max(s.SpanSeconds-s.TotalSeconds, 0)
+}
+
+// SessionSummaries returns one summary per completed (STOPped) session whose first
+// interval began on a local date within [fromDate, toDate] inclusive, ordered by start.
+func SessionSummaries(db *sql.DB, fromDate, toDate string) ([]SessionSummary, error) {
+	from, toExclusive, err := localDateBounds(db, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+SELECT i.session_id, COALESCE(MAX(i.label), ''), MIN(i.start_utc) AS session_start, MAX(i.end_utc), COUNT(*), SUM(i.duration_seconds),
+       (SELECT COALESCE(MAX(e.timestamp_utc) - MIN(e.timestamp_utc), 0)
+        FROM events e WHERE e.session_id = i.session_id AND e.deleted_at IS NULL)
+FROM intervals i
+WHERE i.end_utc IS NOT NULL AND i.deleted_at IS NULL
+  AND EXISTS (SELECT 1 FROM events e WHERE e.session_id = i.session_id AND e.action = 'STOP')
+GROUP BY i.session_id
+HAVING session_start >= ? AND session_start < ?
+ORDER BY session_start;
+`, from.Unix(), toExclusive.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query session summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var res []SessionSummary
+	for rows.Next() {
+		var s SessionSummary
+		var start, end int64
+		if err := rows.Scan(&s.SessionID, &s.Label, &start, &end, &s.Intervals, &s.TotalSeconds, &s.SpanSeconds); err != nil {
+			return nil, err
+		}
+		s.StartUTC = time.Unix(start, 0).UTC()
+		s.EndUTC = time.Unix(end, 0).UTC()
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+// SessionExtremes returns the completed sessions with the most and least worked
+// time within [fromDate, toDate] inclusive (see SessionSummaries). Ties go to the
+// earlier session. If the range has no sessions both results are zero summaries.
+func SessionExtremes(db *sql.DB, fromDate, toDate string) (longest, shortest SessionSummary, err error) {
+	sessions, err := SessionSummaries(db, fromDate, toDate)
+	if err != nil {
+		return SessionSummary{}, SessionSummary{}, err
+	}
+	for i, s := range sessions {
+		if i == 0 || s.TotalSeconds > longest.TotalSeconds {
+			longest = s
+		}
+		if i == 0 || s.TotalSeconds < shortest.TotalSeconds {
+			shortest = s
+		}
+	}
+	return longest, shortest, nil
+}
+
+// IntervalDetail is one interval of a session. EndUTC is zero while it is open.
+type IntervalDetail = storage.IntervalDetail
+
+// SessionDetail returns the aggregate of one session together with each of its
+// intervals in order. Unlike SessionSummaries it accepts sessions that are still
+// running; their open interval is listed but not counted in the totals.
+// It returns sql.ErrNoRows when the session has no intervals.
+func SessionDetail(db *sql.DB, sessionID string) (SessionSummary, []IntervalDetail, error) {
+	rows, err := db.Query(`
+SELECT id, interval_index, start_utc, end_utc, COALESCE(duration_seconds, 0), category, COALESCE(description, ''), COALESCE(label, '')
+FROM intervals
+WHERE session_id = ? AND deleted_at IS NULL
+ORDER BY interval_index, id;
+`, sessionID)
+	if err != nil {
+		return SessionSummary{}, nil, fmt.Errorf("query session intervals: %w", err)
+	}
+	defer rows.Close()
+
+	summary := SessionSummary{SessionID: sessionID}
+	var intervals []IntervalDetail
+	for rows.Next() {
+		var iv IntervalDetail
+		var start int64
+		var end sql.NullInt64
+		if err := rows.Scan(&iv.ID, &iv.Index, &start, &end, &iv.DurationSeconds, &iv.Category, &iv.Description, &summary.Label); err != nil {
+			return SessionSummary{}, nil, err
+		}
+		iv.StartUTC = time.Unix(start, 0).UTC()
+		if len(intervals) == 0 || iv.StartUTC.Before(summary.StartUTC) {
+			summary.StartUTC = iv.StartUTC
+		}
+		if end.Valid {
+			iv.EndUTC = time.Unix(end.Int64, 0).UTC()
+			if iv.EndUTC.After(summary.EndUTC) {
+				summary.EndUTC = iv.EndUTC
+			}
+			summary.Intervals++
+			summary.TotalSeconds += iv.DurationSeconds
+		}
+		intervals = append(intervals, iv)
+	}
+	if err := rows.Err(); err != nil {
+		return SessionSummary{}, nil, err
+	}
+	if len(intervals) == 0 {
+		return SessionSummary{}, nil, sql.ErrNoRows
+	}
+	if err := db.QueryRow(`
+SELECT COALESCE(MAX(timestamp_utc) - MIN(timestamp_utc), 0)
+FROM events
+WHERE session_id = ? AND deleted_at IS NULL;
+`, sessionID).Scan(&summary.SpanSeconds); err != nil {
+		return SessionSummary{}, nil, fmt.Errorf("query session span: %w", err)
+	}
+	return summary, intervals, nil
+}
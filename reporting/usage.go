@@ -0,0 +1,81 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// UsageStats summarizes tracking habits over all completed sessions.
+type UsageStats struct {
+	Sessions              int
+	AverageSessionSeconds int64  // worked time per session, excluding breaks
+	TopCategory           string // category with the most worked time, "" if none
+	TopCategorySeconds    int64
+	TypicalStart          time.Duration // median local time of day sessions start at
+	SessionsPerWeek       float64       // over the weeks from the first session to the last
+}
+
+// UsagePatterns computes UsageStats from every completed (STOPped) session not in
+// the trash. All fields are zero when there are no sessions.
+func UsagePatterns(db *sql.DB) (UsageStats, error) {
+	rows, err := db.Query(`
+SELECT MIN(i.start_utc), SUM(i.duration_seconds)
+FROM intervals i
+WHERE i.end_utc IS NOT NULL AND i.deleted_at IS NULL
+  AND EXISTS (SELECT 1 FROM events e WHERE e.session_id = i.session_id AND e.action = 'STOP')
+GROUP BY i.session_id;
+`)
+	if err != nil {
+		return UsageStats{}, fmt.Errorf("query usage sessions: %w", err)
+	}
+	var stats UsageStats
+	var total int64
+	var first, last time.Time
+	var startsOfDay []time.Duration
+	for rows.Next() {
+		var start, secs int64
+		if err := rows.Scan(&start, &secs); err != nil {
+			rows.Close()
+			return UsageStats{}, err
+		}
+		t := time.Unix(start, 0).In(time.Local)
+		if stats.Sessions == 0 || t.Before(first) {
+			first = t
+		}
+		if stats.Sessions == 0 || t.After(last) {
+			last = t
+		}
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+		startsOfDay = append(startsOfDay, t.Sub(midnight))
+		total += secs
+		stats.Sessions++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return UsageStats{}, err
+	}
+	if stats.Sessions == 0 {
+		return UsageStats{}, nil
+	}
+
+	stats.AverageSessionSeconds = total / int64(stats.Sessions)
+	sort.Slice(startsOfDay, func(i, j int) bool { return startsOfDay[i] < startsOfDay[j] })
+	stats.TypicalStart = startsOfDay[len(startsOfDay)/2]
+	weeks := last.Sub(first).Hours() / (7 * 24)
+	stats.SessionsPerWeek = float64(stats.Sessions) / max(weeks, 1)
+
+	err = db.QueryRow(`
+SELECT category, SUM(duration_seconds) AS total
+FROM intervals
+WHERE end_utc IS NOT NULL AND deleted_at IS NULL
+GROUP BY category
+ORDER BY total DESC, category
+LIMIT 1;
+`).Scan(&stats.TopCategory, &stats.TopCategorySeconds)
+	if err != nil && err != sql.ErrNoRows {
+		return UsageStats{}, fmt.Errorf("query top category: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,114 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sliceToleranceSeconds is how far an interval's day slices may sum from its
+// duration before VerifyDaySlicing reports it.
+const sliceToleranceSeconds = 1
+
+// VerifyDaySlicing checks that each closed interval of the session was sliced
+// into interval_days correctly: the slices' durations must sum to the interval's
+// duration_seconds (within sliceToleranceSeconds) and their dates must be
+// consecutive, one slice per date. ok is true when nothing was found; otherwise
+// each discrepancy is described in a line naming the interval. Running and
+// zero-length intervals have no slices and are skipped, as are trashed rows.
+func VerifyDaySlicing(db *sql.DB, sessionID string) (ok bool, discrepancies []string, err error) {
+	type interval struct {
+		id              int64
+		index           int
+		durationSeconds int64
+	}
+	rows, err := db.Query(`
+SELECT id, interval_index, duration_seconds
+FROM intervals
+WHERE session_id = ? AND end_utc IS NOT NULL AND deleted_at IS NULL AND duration_seconds > 0
+ORDER BY interval_index, id;
+`, sessionID)
+	if err != nil {
+		return false, nil, fmt.Errorf("query session intervals: %w", err)
+	}
+	var intervals []interval
+	for rows.Next() {
+		var iv interval
+		if err := rows.Scan(&iv.id, &iv.index, &iv.durationSeconds); err != nil {
+			rows.Close()
+			return false, nil, err
+		}
+		intervals = append(intervals, iv)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return false, nil, err
+	}
+
+	// Slices are read per interval only after the interval rows are closed,
+	// since an in-memory database has a single connection.
+	for _, iv := range intervals {
+		found, err := verifyIntervalSlices(db, iv.id, iv.durationSeconds)
+		if err != nil {
+			return false, nil, err
+		}
+		for _, f := range found {
+			discrepancies = append(discrepancies, fmt.Sprintf("Interval #%d (id %d): %s", iv.index+1, iv.id, f))
+		}
+	}
+	return len(discrepancies) == 0, discrepancies, nil
+}
+
+// verifyIntervalSlices returns the problems found in one interval's slices.
+func verifyIntervalSlices(db *sql.DB, intervalID, durationSeconds int64) ([]string, error) {
+	rows, err := db.Query(`
+SELECT date_local, duration_seconds
+FROM interval_days
+WHERE interval_id = ? AND deleted_at IS NULL
+ORDER BY date_local;
+`, intervalID)
+	if err != nil {
+		return nil, fmt.Errorf("query interval slices: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	var sum int64
+	var prev time.Time
+	sliceCount := 0
+	for rows.Next() {
+		var dateLocal string
+		var secs int64
+		if err := rows.Scan(&dateLocal, &secs); err != nil {
+			return nil, err
+		}
+		sum += secs
+		sliceCount++
+		date, err := time.Parse("2006-01-02", dateLocal)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("slice has invalid date %q", dateLocal))
+			continue
+		}
+		if !prev.IsZero() {
+			switch next := prev.AddDate(0, 0, 1); {
+			case date.Equal(prev):
+				problems = append(problems, fmt.Sprintf("date %s is sliced more than once", dateLocal))
+			case !date.Equal(next):
+				problems = append(problems, fmt.Sprintf("dates jump from %s to %s", prev.Format("2006-01-02"), dateLocal))
+			}
+		}
+		prev = date
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if sliceCount == 0 {
+		return append(problems, "no day slices"), nil
+	}
+	if diff := sum - durationSeconds; diff > sliceToleranceSeconds || diff < -sliceToleranceSeconds {
+		problems = append(problems, fmt.Sprintf("slices sum to %ds but the interval lasts %ds", sum, durationSeconds))
+	}
+	return problems, nil
+}
@@ -0,0 +1,28 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// WeekdayNames labels the buckets returned by TotalsByWeekday.
+var WeekdayNames = [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// TotalsByWeekday returns duration_seconds summed by the weekday of date_local for
+// local dates within [fromDate, toDate] inclusive. Index 0 is Monday, 6 is Sunday.
+func TotalsByWeekday(db *sql.DB, fromDate, toDate string) ([7]int64, error) {
+	var res [7]int64
+	days, err := TotalsByDay(db, fromDate, toDate)
+	if err != nil {
+		return res, err
+	}
+	for _, d := range days {
+		date, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			return res, fmt.Errorf("parse date %q: %w", d.Date, err)
+		}
+		res[(int(date.Weekday())+6)%7] += d.TotalSeconds
+	}
+	return res, nil
+}
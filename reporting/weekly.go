@@ -0,0 +1,95 @@
+package reporting
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// WeekTotal is the total duration recorded in one Monday-to-Sunday week.
+type WeekTotal struct {
+	WeekStart    string // Monday, 'YYYY-MM-DD'
+	TotalSeconds int64
+}
+
+// TotalsByWeek returns duration_seconds summed per week for local dates within
+// [fromDate, toDate] inclusive, ordered by week. Weeks start on Monday; weeks
+// without work are omitted. Partial weeks at either end only include days in range.
+func TotalsByWeek(db *sql.DB, fromDate, toDate string) ([]WeekTotal, error) {
+	days, err := TotalsByDay(db, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []WeekTotal
+	for _, d := range days {
+		date, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			return nil, fmt.Errorf("parse date %q: %w", d.Date, err)
+		}
+		ws := weekStart(date).Format("2006-01-02")
+		if n := len(res); n > 0 && res[n-1].WeekStart == ws {
+			res[n-1].TotalSeconds += d.TotalSeconds
+			continue
+		}
+		res = append(res, WeekTotal{WeekStart: ws, TotalSeconds: d.TotalSeconds})
+	}
+	return res, nil
+}
+
+// GoalStreak counts consecutive weeks whose total reached goalSeconds. The week
+// containing asOf is still in progress and is not counted; currentStreak runs back
+// from the week before it, and longestStreak is the best run in all recorded history.
+func GoalStreak(db *sql.DB, goalSeconds int64, asOf time.Time) (currentStreak, longestStreak int, err error) {
+	if goalSeconds <= 0 {
+		return 0, 0, fmt.Errorf("weekly goal must be positive")
+	}
+
+	var first sql.NullString
+	if err := db.QueryRow(`SELECT MIN(date_local) FROM interval_days WHERE deleted_at IS NULL;`).Scan(&first); err != nil {
+		return 0, 0, fmt.Errorf("query first date: %w", err)
+	}
+	if !first.Valid {
+		return 0, 0, nil
+	}
+	firstDate, err := time.Parse("2006-01-02", first.String)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse date %q: %w", first.String, err)
+	}
+
+	// Only complete weeks: up to the Sunday before asOf's week
+	y, m, d := asOf.In(time.Local).Date()
+	currentWeek := weekStart(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	lastDay := currentWeek.AddDate(0, 0, -1)
+	if lastDay.Before(firstDate) {
+		return 0, 0, nil
+	}
+	weeks, err := TotalsByWeek(db, first.String, lastDay.Format("2006-01-02"))
+	if err != nil {
+		return 0, 0, err
+	}
+	met := make(map[string]bool, len(weeks))
+	for _, w := range weeks {
+		met[w.WeekStart] = w.TotalSeconds >= goalSeconds
+	}
+
+	// Walk every week oldest to newest so gaps without any work break the run
+	run := 0
+	for ws := weekStart(firstDate); ws.Before(currentWeek); ws = ws.AddDate(0, 0, 7) {
+		if met[ws.Format("2006-01-02")] {
+			run++
+			if run > longestStreak {
+				longestStreak = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return run, longestStreak, nil
+}
+
+// weekStart returns the Monday on or before date (a UTC midnight calendar date).
+func weekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7 // Monday = 0
+	return date.AddDate(0, 0, -offset)
+}
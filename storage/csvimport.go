@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NoProjectCategory is the category of imported entries that have no project.
+const NoProjectCategory = "(no project)"
+
+// csvImportFormat names the columns of another time tracker's CSV export.
+// Column names are matched ignoring case and surrounding spaces.
+type csvImportFormat struct {
+	name        string
+	startDate   string
+	startTime   string
+	duration    string // HH:MM:SS
+	project     string
+	description string
+	billable    string // "Yes"/"No"; optional
+}
+
+var (
+	togglFormat = csvImportFormat{
+		name: "Toggl", startDate: "Start date", startTime: "Start time", duration: "Duration",
+		project: "Project", description: "Description", billable: "Billable",
+	}
+	clockifyFormat = csvImportFormat{
+		name: "Clockify", startDate: "Start Date", startTime: "Start Time", duration: "Duration (h)",
+		project: "Project", description: "Description", billable: "Billable",
+	}
+)
+
+// csvImportDateLayouts and csvImportTimeLayouts are the date and time formats
+// accepted in imported files; exports vary with the user's locale settings.
+var (
+	csvImportDateLayouts = []string{"2006-01-02", "01/02/2006", "02.01.2006"}
+	csvImportTimeLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "03:04 PM"}
+)
+
+// CSVImportOverlap is an imported entry that shares time with intervals
+// already recorded (see FindOverlaps).
+type CSVImportOverlap struct {
+	Line        int // line of the entry in the file; the header is line 1
+	StartUTC    time.Time
+	EndUTC      time.Time
+	Category    string
+	Description string
+	Existing    []IntervalDetail // the intervals it overlaps, ordered by start
+}
+
+// ImportTogglCSV imports the entries of a Toggl Track detailed CSV export. Each
+// entry becomes a completed single-interval session (see InsertCompletedSession)
+// in its project's category, starting at its local start date and time and
+// lasting its Duration (HH:MM:SS). Entries without a project are filed under
+// NoProjectCategory. Entries with a zero or unreadable duration or start are
+// skipped.
+//
+// overlaps lists the entries that overlap time already recorded, including
+// entries imported earlier from the same file; with skipOverlaps they are
+// skipped too, so importing the same file twice adds nothing, otherwise they
+// are imported so the caller can warn about double counting. The whole file
+// is imported in one transaction, and its events are recorded as written by
+// appVersion. With dryRun the transaction is rolled back, so the results
+// describe what importing would do without changing anything.
+func ImportTogglCSV(db *sql.DB, r io.Reader, appVersion string, skipOverlaps, dryRun bool) (imported, skipped int, overlaps []CSVImportOverlap, err error) {
+	return importCSVEntries(db, r, togglFormat, appVersion, skipOverlaps, dryRun)
+}
+
+// ImportClockifyCSV is ImportTogglCSV for Clockify's detailed CSV export.
+func ImportClockifyCSV(db *sql.DB, r io.Reader, appVersion string, skipOverlaps, dryRun bool) (imported, skipped int, overlaps []CSVImportOverlap, err error) {
+	return importCSVEntries(db, r, clockifyFormat, appVersion, skipOverlaps, dryRun)
+}
+
+func importCSVEntries(db *sql.DB, r io.Reader, format csvImportFormat, appVersion string, skipOverlaps, dryRun bool) (imported, skipped int, overlaps []CSVImportOverlap, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return 0, 0, nil, fmt.Errorf("the file is empty")
+	}
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("read header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		// Spreadsheet tools may prefix the first column with a byte order mark
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	col := func(name string) (int, bool) {
+		i, ok := columns[strings.ToLower(name)]
+		return i, ok
+	}
+	required := []string{format.startDate, format.startTime, format.duration, format.project, format.description}
+	for _, name := range required {
+		if _, ok := col(name); !ok {
+			return 0, 0, nil, fmt.Errorf("not a %s CSV export: missing column %q", format.name, name)
+		}
+	}
+
+	loc, boundary := slicingSettings(db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	defer tx.Rollback()
+
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		field := func(name string) string {
+			if i, ok := col(name); ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		start, err := parseImportedStart(field(format.startDate), field(format.startTime))
+		if err != nil {
+			skipped++
+			continue
+		}
+		duration, err := parseClockDuration(field(format.duration))
+		if err != nil || duration <= 0 {
+			skipped++
+			continue
+		}
+		startUTC, endUTC := start.UTC(), start.Add(duration).UTC()
+
+		category := field(format.project)
+		if category == "" {
+			category = NoProjectCategory
+		}
+		existing, err := findOverlaps(tx, startUTC, endUTC)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(existing) > 0 {
+			overlaps = append(overlaps, CSVImportOverlap{
+				Line: line, StartUTC: startUTC, EndUTC: endUTC,
+				Category: category, Description: field(format.description), Existing: existing,
+			})
+			if skipOverlaps {
+				skipped++
+				continue
+			}
+		}
+
+		billable := !strings.EqualFold(field(format.billable), "no")
+		if err := insertCompletedSession(tx, uuid.NewString(), startUTC, endUTC, category, field(format.description), appVersion, billable, loc, boundary); err != nil {
+			return 0, 0, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		imported++
+	}
+
+	if dryRun {
+		return imported, skipped, overlaps, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, nil, fmt.Errorf("commit import: %w", err)
+	}
+	return imported, skipped, overlaps, nil
+}
+
+// parseImportedStart combines a date and a time of day in the local zone,
+// trying each accepted layout.
+func parseImportedStart(date, clock string) (time.Time, error) {
+	for _, dl := range csvImportDateLayouts {
+		for _, tl := range csvImportTimeLayouts {
+			if t, err := time.ParseInLocation(dl+" "+tl, date+" "+clock, time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized start %q %q", date, clock)
+}
+
+// parseClockDuration parses an "HH:MM:SS" duration. Hours may exceed 24.
+func parseClockDuration(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, errors.New("duration must be HH:MM:SS")
+	}
+	var total time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportCSVOverlaps(t *testing.T) {
+	const file = "Project,Description,Start date,Start time,Duration\n" +
+		"Dev,clash,2026-03-02,09:30:00,01:00:00\n" +
+		"Dev,free,2026-03-02,14:00:00,01:00:00\n"
+
+	for _, tc := range []struct {
+		name                 string
+		skipOverlaps, dryRun bool
+		wantImported         int
+		wantSkipped          int
+		wantSessions         int // in the database afterwards, including the existing one
+	}{
+		{"skip", true, false, 1, 1, 2},
+		{"import", false, false, 2, 0, 3},
+		{"dry run", false, true, 2, 0, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db := openTestDB(t)
+			start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local).UTC()
+			insertSession(t, db, "existing", start, start.Add(time.Hour))
+
+			imported, skipped, overlaps, err := ImportTogglCSV(db, strings.NewReader(file), "1.0.0", tc.skipOverlaps, tc.dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if imported != tc.wantImported || skipped != tc.wantSkipped {
+				t.Errorf("imported %d, skipped %d; want %d, %d", imported, skipped, tc.wantImported, tc.wantSkipped)
+			}
+			if len(overlaps) != 1 {
+				t.Fatalf("got %d overlaps, want 1: %+v", len(overlaps), overlaps)
+			}
+			o := overlaps[0]
+			if o.Line != 2 || o.Description != "clash" || len(o.Existing) != 1 || !o.Existing[0].StartUTC.Equal(start) {
+				t.Errorf("overlap = %+v, want line 2 \"clash\" overlapping the interval starting %v", o, start)
+			}
+
+			var sessions int
+			if err := db.QueryRow(`SELECT COUNT(DISTINCT session_id) FROM intervals;`).Scan(&sessions); err != nil {
+				t.Fatal(err)
+			}
+			if sessions != tc.wantSessions {
+				t.Errorf("%d sessions recorded, want %d", sessions, tc.wantSessions)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package storage
 import (
 	"database/sql"
 	"fmt"
+	"net/url"
+	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -11,22 +14,33 @@ import (
 
 // OpenAndMigrate opens SQLite database and runs migrations.
 // It sets PRAGMA user_version for schema versioning.
+// dbPath may be a plain file path, ":memory:", or a DSN such as
+// "file:tracker.db?cache=shared"; only plain file paths are made absolute.
 func OpenAndMigrate(dbPath string) (*sql.DB, error) {
 	// Modernc sqlite uses file path as DSN; ensure absolute path for clarity.
-	abs := dbPath
-	if !filepath.IsAbs(dbPath) {
+	dsn := dbPath
+	if !isRawDSN(dbPath) && !filepath.IsAbs(dbPath) {
 		var err error
-		abs, err = filepath.Abs(dbPath)
+		dsn, err = filepath.Abs(dbPath)
 		if err != nil {
 			return nil, fmt.Errorf("cannot resolve absolute path: %w", err)
 		}
 	}
 
-	db, err := sql.Open("sqlite", abs)
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite: %w", err)
 	}
 
+	// Each connection to ":memory:" gets its own private database, so pin the
+	// pool to a single connection to keep schema and data visible everywhere.
+	// A transaction or open rows then hold that only connection, so anything
+	// else needed (settings, see slicingSettings) must be read before db.Begin
+	// and never queried through db while rows are open.
+	if dbPath == ":memory:" {
+		db.SetMaxOpenConns(1)
+	}
+
 	if _, err := db.Exec(`PRAGMA foreign_keys = ON;`); err != nil {
 		return nil, fmt.Errorf("enable foreign keys: %w", err)
 	}
@@ -39,100 +53,36 @@ func OpenAndMigrate(dbPath string) (*sql.DB, error) {
 	return db, nil
 }
 
-func migrate(db *sql.DB) error {
-	// Read current version
-	var userVersion int
-	if err := db.QueryRow(`PRAGMA user_version;`).Scan(&userVersion); err != nil {
-		return fmt.Errorf("read user_version: %w", err)
+// OpenReadOnly opens an existing SQLite database for reading only, for reporting
+// tools that run alongside the app. Migrations are skipped, so the file is never
+// modified; any write through the returned handle fails.
+func OpenReadOnly(dbPath string) (*sql.DB, error) {
+	abs, err := filepath.Abs(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve absolute path: %w", err)
 	}
-
-	// Version 1: create events, intervals, interval_days
-	if userVersion < 1 {
-		tx, err := db.Begin()
-		if err != nil {
-			return err
-		}
-		defer tx.Rollback()
-
-		// Event log: ground truth audit
-		if _, err := tx.Exec(`
-CREATE TABLE IF NOT EXISTS events (
-    id             INTEGER PRIMARY KEY AUTOINCREMENT,
-    session_id     TEXT NOT NULL,
-    timestamp_utc  INTEGER NOT NULL, -- epoch seconds
-    action         TEXT NOT NULL CHECK (action IN ('START','PAUSE','RESUME','STOP')),
-    category       TEXT NOT NULL,
-    description    TEXT,
-    user_tz        TEXT
-);`); err != nil {
-			return fmt.Errorf("create events: %w", err)
-		}
-
-		// Intervals: open/close slices
-		if _, err := tx.Exec(`
-CREATE TABLE IF NOT EXISTS intervals (
-    id               INTEGER PRIMARY KEY AUTOINCREMENT,
-    session_id       TEXT NOT NULL,
-    interval_index   INTEGER NOT NULL,
-    start_utc        INTEGER NOT NULL,
-    end_utc          INTEGER,            -- NULL until closed
-    category         TEXT NOT NULL,
-    description      TEXT,
-    duration_seconds INTEGER             -- set when closed
-);`); err != nil {
-			return fmt.Errorf("create intervals: %w", err)
-		}
-
-		// Daily materialization: fast reporting by day/week/month
-		if _, err := tx.Exec(`
-CREATE TABLE IF NOT EXISTS interval_days (
-    id               INTEGER PRIMARY KEY AUTOINCREMENT,
-    interval_id      INTEGER NOT NULL,
-    session_id       TEXT NOT NULL,
-    date_local       TEXT NOT NULL,      -- 'YYYY-MM-DD'
-    category         TEXT NOT NULL,
-    description      TEXT,
-    duration_seconds INTEGER NOT NULL,
-    FOREIGN KEY (interval_id) REFERENCES intervals(id) ON DELETE CASCADE
-);`); err != nil {
-			return fmt.Errorf("create interval_days: %w", err)
-		}
-
-		if _, err := tx.Exec(`PRAGMA user_version = 1;`); err != nil {
-			return fmt.Errorf("set user_version: %w", err)
-		}
-
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("commit migration v1: %w", err)
-		}
+	if _, err := os.Stat(abs); err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
 	}
 
-	// Version 2: create settings table
-	if userVersion < 2 {
-		tx, err := db.Begin()
-		if err != nil {
-			return err
-		}
-		defer tx.Rollback()
-
-		if _, err := tx.Exec(`
-CREATE TABLE IF NOT EXISTS settings (
-    key TEXT PRIMARY KEY,
-    value TEXT NOT NULL
-);`); err != nil {
-			return fmt.Errorf("create settings: %w", err)
-		}
-
-		if _, err := tx.Exec(`PRAGMA user_version = 2;`); err != nil {
-			return fmt.Errorf("set user_version: %w", err)
-		}
-
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("commit migration v2: %w", err)
-		}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs), RawQuery: "mode=ro"}
+	db, err := sql.Open("sqlite", u.String())
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
 	}
+	// sql.Open is lazy; make sure the file really is a readable database
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version;`).Scan(&version); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	return db, nil
+}
 
-	return nil
+// isRawDSN reports whether dbPath should be handed to the driver unmodified:
+// the special ":memory:" name, "file:" URIs, or anything carrying query parameters.
+func isRawDSN(dbPath string) bool {
+	return dbPath == ":memory:" || strings.HasPrefix(dbPath, "file:") || strings.Contains(dbPath, "?")
 }
 
 // GetSetting retrieves a setting value from the database, returning defaultValue if not found.
@@ -154,24 +104,144 @@ ON CONFLICT(key) DO UPDATE SET value = excluded.value;
 	return err
 }
 
-// InsertEvent writes an event row.
+// ReportLocation returns the zone new intervals are bucketed into days in, from
+// the "report_timezone" setting: "Local" (the default), "UTC", or an IANA zone
+// name such as "Europe/Berlin". An unknown zone falls back to Local.
+func ReportLocation(db *sql.DB) *time.Location {
+	loc, err := loadZone(GetSetting(db, "report_timezone", "Local"))
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// DayBoundary returns how long after local midnight a day starts when slicing
+// intervals into days (setting day_boundary, "HH:MM"). Work before the boundary
+// counts toward the previous date. Missing or invalid values mean midnight.
+func DayBoundary(db *sql.DB) time.Duration {
+	d, err := ParseDayBoundary(GetSetting(db, "day_boundary", "00:00"))
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// slicingSettings returns the zone and day boundary new interval_days rows are
+// sliced with. Callers read them before beginning their transaction, which may
+// hold the only connection (see OpenAndMigrate).
+func slicingSettings(db *sql.DB) (loc *time.Location, boundary time.Duration) {
+	return ReportLocation(db), DayBoundary(db)
+}
+
+// ParseDayBoundary parses a day boundary in the form "HH:MM", between 00:00 and 23:59.
+func ParseDayBoundary(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day in the form HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// DayBounds returns the instants [start, end) of local date dateLocal
+// ('YYYY-MM-DD') as interval_days slices it: from the boundary on that date in
+// loc to the boundary on the next, on the wall clock so DST days stay correct.
+func DayBounds(dateLocal string, loc *time.Location, boundary time.Duration) (start, end time.Time, err error) {
+	day, err := time.ParseInLocation("2006-01-02", dateLocal, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	bh, bm := int(boundary/time.Hour), int(boundary%time.Hour/time.Minute)
+	start = time.Date(day.Year(), day.Month(), day.Day(), bh, bm, 0, 0, loc)
+	end = time.Date(day.Year(), day.Month(), day.Day()+1, bh, bm, 0, 0, loc)
+	return start, end, nil
+}
+
+// ReportDate returns the local date ('YYYY-MM-DD') that the instant t counts
+// toward as interval_days slices it: its date in ReportLocation, or the
+// previous one when it falls before the DayBoundary.
+func ReportDate(db *sql.DB, t time.Time) string {
+	loc, boundary := slicingSettings(db)
+	local := t.In(loc)
+	bh, bm := int(boundary/time.Hour), int(boundary%time.Hour/time.Minute)
+	if local.Before(time.Date(local.Year(), local.Month(), local.Day(), bh, bm, 0, 0, loc)) {
+		local = local.AddDate(0, 0, -1)
+	}
+	return local.Format("2006-01-02")
+}
+
+// loadZone resolves a zone name as stored in settings and interval_days.zone.
+func loadZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// InsertEvent writes an event row. Empty issueID, label and appVersion are stored as NULL.
 // We store user_tz as best-effort (system tz name) for debugging. Not required for logic.
-func InsertEvent(db *sql.DB, sessionID string, whenUTC time.Time, action, category, description string) error {
+func InsertEvent(db *sql.DB, sessionID string, whenUTC time.Time, action, category, description, issueID, label, appVersion string, billable bool) error {
 	userTZName := time.Local.String() // e.g., "Local" or a location name depending on system config
 
 	_, err := db.Exec(`
-INSERT INTO events (session_id, timestamp_utc, action, category, description, user_tz)
-VALUES (?, ?, ?, ?, ?, ?);
-`, sessionID, whenUTC.Unix(), action, category, description, userTZName)
+INSERT INTO events (session_id, timestamp_utc, action, category, description, user_tz, issue_id, label, app_version, billable)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+`, sessionID, whenUTC.Unix(), action, category, description, userTZName, nullIfEmpty(issueID), nullIfEmpty(label), nullIfEmpty(appVersion), billable)
+	return err
+}
+
+// nullIfEmpty maps "" to NULL for optional text columns.
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// SetLastEventReason stores reason on the session's most recent event with the
+// given action. An empty reason is stored as NULL.
+func SetLastEventReason(db *sql.DB, sessionID, action, reason string) error {
+	_, err := db.Exec(`
+UPDATE events
+SET reason = ?
+WHERE id = (SELECT MAX(id) FROM events WHERE session_id = ? AND action = ?);
+`, nullIfEmpty(reason), sessionID, action)
+	return err
+}
+
+// OpenInterval inserts a new open interval row. Empty issueID and label are stored as NULL.
+// The checkpoint (last_seen_utc) starts at the interval start.
+func OpenInterval(db *sql.DB, sessionID string, intervalIndex int, startUTC time.Time, category, description, issueID, label string, billable bool) error {
+	_, err := db.Exec(`
+INSERT INTO intervals (session_id, interval_index, start_utc, category, description, last_seen_utc, issue_id, label, billable)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+`, sessionID, intervalIndex, startUTC.Unix(), category, description, startUTC.Unix(), nullIfEmpty(issueID), nullIfEmpty(label), billable)
 	return err
 }
 
-// OpenInterval inserts a new open interval row.
-func OpenInterval(db *sql.DB, sessionID string, intervalIndex int, startUTC time.Time, category, description string) error {
+// SetSessionBillable marks every event and interval of the session billable or
+// not. The flag belongs to the session as a whole, so past intervals follow it.
+func SetSessionBillable(db *sql.DB, sessionID string, billable bool) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, table := range []string{"events", "intervals"} {
+		if _, err := tx.Exec(`UPDATE `+table+` SET billable = ? WHERE session_id = ?;`, billable, sessionID); err != nil {
+			return fmt.Errorf("update %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// CheckpointOpenInterval records that the open interval of the session was still
+// being tracked at seenUTC. After a crash the interval is capped at this time.
+func CheckpointOpenInterval(db *sql.DB, sessionID string, seenUTC time.Time) error {
 	_, err := db.Exec(`
-INSERT INTO intervals (session_id, interval_index, start_utc, category, description)
-VALUES (?, ?, ?, ?, ?);
-`, sessionID, intervalIndex, startUTC.Unix(), category, description)
+UPDATE intervals
+SET last_seen_utc = ?
+WHERE session_id = ? AND end_utc IS NULL;
+`, seenUTC.Unix(), sessionID)
 	return err
 }
 
@@ -179,10 +249,19 @@ VALUES (?, ?, ?, ?, ?);
 // writes duration, and slices into interval_days across local midnight boundaries.
 // If multiple open intervals exist (shouldn't), it closes the latest one.
 func CloseOpenIntervalAndSliceDays(db *sql.DB, sessionID string, startUTC, endUTC time.Time, category, description string) error {
+	loc, boundary := slicingSettings(db)
+
+	// Closing and slicing happen in one transaction so they can't disagree.
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	// Close the open interval: set end_utc and duration_seconds.
 	// Find the interval id by session_id and end_utc IS NULL and start_utc == startUTC.
 	var intervalID int64
-	err := db.QueryRow(`
+	err = tx.QueryRow(`
 SELECT id FROM intervals
 WHERE session_id = ? AND end_utc IS NULL
 ORDER BY id DESC
@@ -197,25 +276,331 @@ LIMIT 1;
 		durationSeconds = 0
 	}
 
-	if _, err := db.Exec(`
+	if _, err := tx.Exec(`
 UPDATE intervals
 SET end_utc = ?, duration_seconds = ?
 WHERE id = ?;`, endUTC.Unix(), durationSeconds, intervalID); err != nil {
 		return fmt.Errorf("close interval: %w", err)
 	}
 
-	// Slice into interval_days using system local timezone at close time.
-	if err := sliceIntervalIntoDays(db, intervalID, sessionID, startUTC, endUTC, category, description, time.Local); err != nil {
+	// Slice into interval_days using the configured report timezone at close time.
+	if err := sliceIntervalIntoDays(tx, intervalID, sessionID, startUTC, endUTC, category, description, loc, boundary); err != nil {
 		return fmt.Errorf("slice interval days: %w", err)
 	}
 
+	return tx.Commit()
+}
+
+// InsertCompletedSession records a whole single-interval session at once: a START
+// event at startUTC, a closed interval sliced into interval_days, and a STOP event
+// at endUTC, all in one transaction. It is used for work logged after the fact.
+func InsertCompletedSession(db *sql.DB, sessionID string, startUTC, endUTC time.Time, category, description, appVersion string, billable bool) error {
+	loc, boundary := slicingSettings(db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := insertCompletedSession(tx, sessionID, startUTC, endUTC, category, description, appVersion, billable, loc, boundary); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertCompletedSession writes the rows of InsertCompletedSession within tx,
+// slicing days in loc with the given day boundary.
+func insertCompletedSession(tx *sql.Tx, sessionID string, startUTC, endUTC time.Time, category, description, appVersion string, billable bool, loc *time.Location, boundary time.Duration) error {
+	userTZName := time.Local.String()
+	for _, ev := range []struct {
+		action string
+		at     time.Time
+	}{{"START", startUTC}, {"STOP", endUTC}} {
+		if _, err := tx.Exec(`
+INSERT INTO events (session_id, timestamp_utc, action, category, description, user_tz, app_version, billable)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+`, sessionID, ev.at.Unix(), ev.action, category, description, userTZName, nullIfEmpty(appVersion), billable); err != nil {
+			return fmt.Errorf("insert %s event: %w", ev.action, err)
+		}
+	}
+
+	res, err := tx.Exec(`
+INSERT INTO intervals (session_id, interval_index, start_utc, end_utc, category, description, duration_seconds, last_seen_utc, billable)
+VALUES (?, 0, ?, ?, ?, ?, ?, ?, ?);
+`, sessionID, startUTC.Unix(), endUTC.Unix(), category, description, int64(endUTC.Sub(startUTC).Seconds()), endUTC.Unix(), billable)
+	if err != nil {
+		return fmt.Errorf("insert interval: %w", err)
+	}
+	intervalID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	if err := sliceIntervalIntoDays(tx, intervalID, sessionID, startUTC, endUTC, category, description, loc, boundary); err != nil {
+		return fmt.Errorf("slice interval days: %w", err)
+	}
 	return nil
 }
 
-// sliceIntervalIntoDays splits [startUTC, endUTC) across local date boundaries
+// DiscardOpenInterval deletes the session's open interval together with the
+// START/RESUME event that opened it, as if it never happened. It returns the
+// action of the session's latest remaining event, or "" if none is left.
+func DiscardOpenInterval(db *sql.DB, sessionID string) (lastAction string, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM intervals WHERE session_id = ? AND end_utc IS NULL;`, sessionID); err != nil {
+		return "", fmt.Errorf("delete open interval: %w", err)
+	}
+	if _, err := tx.Exec(`
+DELETE FROM events
+WHERE id = (SELECT MAX(id) FROM events WHERE session_id = ?)
+  AND action IN ('START', 'RESUME');
+`, sessionID); err != nil {
+		return "", fmt.Errorf("delete opening event: %w", err)
+	}
+	err = tx.QueryRow(`
+SELECT action FROM events WHERE session_id = ? ORDER BY id DESC LIMIT 1;
+`, sessionID).Scan(&lastAction)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("read last event: %w", err)
+	}
+	return lastAction, tx.Commit()
+}
+
+// ClosedInterval is a closed row of the intervals table.
+type ClosedInterval struct {
+	ID          int64
+	SessionID   string
+	StartUTC    time.Time
+	EndUTC      time.Time
+	Category    string
+	Description string
+}
+
+// LastClosedInterval returns the most recently closed interval.
+// It returns sql.ErrNoRows when no interval has been closed yet.
+func LastClosedInterval(db *sql.DB) (ClosedInterval, error) {
+	var iv ClosedInterval
+	var startUTC, endUTC int64
+	var description sql.NullString
+	err := db.QueryRow(`
+SELECT id, session_id, start_utc, end_utc, category, description
+FROM intervals
+WHERE end_utc IS NOT NULL AND deleted_at IS NULL
+ORDER BY end_utc DESC, id DESC
+LIMIT 1;
+`).Scan(&iv.ID, &iv.SessionID, &startUTC, &endUTC, &iv.Category, &description)
+	if err != nil {
+		return iv, err
+	}
+	iv.StartUTC = time.Unix(startUTC, 0).UTC()
+	iv.EndUTC = time.Unix(endUTC, 0).UTC()
+	iv.Description = description.String
+	return iv, nil
+}
+
+// ResliceInterval moves a closed interval to [startUTC, endUTC): it updates the
+// interval's bounds and duration, drops its interval_days rows, and slices it again.
+func ResliceInterval(db *sql.DB, intervalID int64, startUTC, endUTC time.Time) error {
+	_, boundary := slicingSettings(db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sessionID, category string
+	var description, zone sql.NullString
+	if err := tx.QueryRow(`
+SELECT session_id, category, description,
+       (SELECT zone FROM interval_days WHERE interval_id = intervals.id LIMIT 1)
+FROM intervals
+WHERE id = ? AND end_utc IS NOT NULL AND deleted_at IS NULL;
+`, intervalID).Scan(&sessionID, &category, &description, &zone); err != nil {
+		return fmt.Errorf("find interval: %w", err)
+	}
+
+	// Keep the zone the interval was originally bucketed in
+	loc := time.Local
+	if zone.Valid {
+		if l, err := loadZone(zone.String); err == nil {
+			loc = l
+		}
+	}
+
+	durationSeconds := int64(endUTC.Sub(startUTC).Seconds())
+	if durationSeconds < 0 {
+		durationSeconds = 0
+	}
+	if _, err := tx.Exec(`
+UPDATE intervals
+SET start_utc = ?, end_utc = ?, duration_seconds = ?
+WHERE id = ?;`, startUTC.Unix(), endUTC.Unix(), durationSeconds, intervalID); err != nil {
+		return fmt.Errorf("update interval: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM interval_days WHERE interval_id = ?;`, intervalID); err != nil {
+		return fmt.Errorf("delete interval_days: %w", err)
+	}
+	if err := sliceIntervalIntoDays(tx, intervalID, sessionID, startUTC, endUTC, category, description.String, loc, boundary); err != nil {
+		return fmt.Errorf("slice interval days: %w", err)
+	}
+	return tx.Commit()
+}
+
+// SplitInterval cuts a closed interval in two at atUTC, which must fall strictly
+// between its start and end. The original keeps [start, at) as firstCategory and
+// a new interval covers [at, end) as secondCategory with the same description,
+// issue and label. The new interval takes the next interval_index and the
+// session's later intervals (including an open one) are renumbered after it.
+// Both parts are re-sliced into interval_days in the zone the original was
+// bucketed in.
+func SplitInterval(db *sql.DB, intervalID int64, atUTC time.Time, firstCategory, secondCategory string) error {
+	_, boundary := slicingSettings(db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sessionID string
+	var index int
+	var startUnix, endUnix int64
+	var description, issueID, label, zone sql.NullString
+	var billable bool
+	if err := tx.QueryRow(`
+SELECT session_id, interval_index, start_utc, end_utc, description, issue_id, label, billable,
+       (SELECT zone FROM interval_days WHERE interval_id = intervals.id LIMIT 1)
+FROM intervals
+WHERE id = ? AND end_utc IS NOT NULL AND deleted_at IS NULL;
+`, intervalID).Scan(&sessionID, &index, &startUnix, &endUnix, &description, &issueID, &label, &billable, &zone); err != nil {
+		return fmt.Errorf("find interval: %w", err)
+	}
+
+	startUTC, endUTC := time.Unix(startUnix, 0).UTC(), time.Unix(endUnix, 0).UTC()
+	atUTC = time.Unix(atUTC.Unix(), 0).UTC()
+	if !atUTC.After(startUTC) || !atUTC.Before(endUTC) {
+		return fmt.Errorf("split time must be between %s and %s", startUTC.Local().Format("2006-01-02 15:04:05"), endUTC.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	loc := time.Local
+	if zone.Valid {
+		if l, err := loadZone(zone.String); err == nil {
+			loc = l
+		}
+	}
+
+	if _, err := tx.Exec(`
+UPDATE intervals
+SET end_utc = ?, duration_seconds = ?, category = ?
+WHERE id = ?;`, atUTC.Unix(), int64(atUTC.Sub(startUTC).Seconds()), firstCategory, intervalID); err != nil {
+		return fmt.Errorf("update interval: %w", err)
+	}
+	if _, err := tx.Exec(`
+UPDATE intervals
+SET interval_index = interval_index + 1
+WHERE session_id = ? AND interval_index > ?;`, sessionID, index); err != nil {
+		return fmt.Errorf("renumber intervals: %w", err)
+	}
+	res, err := tx.Exec(`
+INSERT INTO intervals (session_id, interval_index, start_utc, end_utc, category, description, duration_seconds, issue_id, label, billable)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		sessionID, index+1, atUTC.Unix(), endUTC.Unix(), secondCategory, description, int64(endUTC.Sub(atUTC).Seconds()), issueID, label, billable)
+	if err != nil {
+		return fmt.Errorf("insert interval: %w", err)
+	}
+	secondID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM interval_days WHERE interval_id = ?;`, intervalID); err != nil {
+		return fmt.Errorf("delete interval_days: %w", err)
+	}
+	if err := sliceIntervalIntoDays(tx, intervalID, sessionID, startUTC, atUTC, firstCategory, description.String, loc, boundary); err != nil {
+		return fmt.Errorf("slice interval days: %w", err)
+	}
+	if err := sliceIntervalIntoDays(tx, secondID, sessionID, atUTC, endUTC, secondCategory, description.String, loc, boundary); err != nil {
+		return fmt.Errorf("slice interval days: %w", err)
+	}
+	return tx.Commit()
+}
+
+// RebuildIntervalDays recreates the interval_days materialization from the
+// intervals table: every row is deleted and each closed interval is sliced again,
+// in the zone its rows were originally computed in (Local if unknown). Rows of
+// trashed intervals stay in the trash.
+func RebuildIntervalDays(db *sql.DB) error {
+	_, boundary := slicingSettings(db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	type closedInterval struct {
+		id, startUTC, endUTC int64
+		sessionID, category  string
+		description, zone    sql.NullString
+	}
+	rows, err := tx.Query(`
+SELECT id, session_id, start_utc, end_utc, category, description,
+       (SELECT zone FROM interval_days WHERE interval_id = intervals.id LIMIT 1)
+FROM intervals
+WHERE end_utc IS NOT NULL
+ORDER BY id;
+`)
+	if err != nil {
+		return fmt.Errorf("query intervals: %w", err)
+	}
+	var intervals []closedInterval
+	for rows.Next() {
+		var iv closedInterval
+		if err := rows.Scan(&iv.id, &iv.sessionID, &iv.startUTC, &iv.endUTC, &iv.category, &iv.description, &iv.zone); err != nil {
+			rows.Close()
+			return err
+		}
+		intervals = append(intervals, iv)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM interval_days;`); err != nil {
+		return fmt.Errorf("delete interval_days: %w", err)
+	}
+	for _, iv := range intervals {
+		loc := time.Local
+		if iv.zone.Valid {
+			if l, err := loadZone(iv.zone.String); err == nil {
+				loc = l
+			}
+		}
+		if err := sliceIntervalIntoDays(tx, iv.id, iv.sessionID, time.Unix(iv.startUTC, 0).UTC(), time.Unix(iv.endUTC, 0).UTC(),
+			iv.category, iv.description.String, loc, boundary); err != nil {
+			return fmt.Errorf("slice interval %d: %w", iv.id, err)
+		}
+	}
+	if _, err := tx.Exec(`
+UPDATE interval_days
+SET deleted_at = (SELECT deleted_at FROM intervals WHERE intervals.id = interval_days.interval_id)
+WHERE interval_id IN (SELECT id FROM intervals WHERE deleted_at IS NOT NULL);`); err != nil {
+		return fmt.Errorf("keep trashed interval_days: %w", err)
+	}
+	return tx.Commit()
+}
+
+// sliceIntervalIntoDays splits [startUTC, endUTC) across date boundaries in loc
 // and inserts rows into interval_days. Durations are computed using UTC differences
-// for accuracy across DST, but dates are labeled in local ('YYYY-MM-DD').
-func sliceIntervalIntoDays(db *sql.DB, intervalID int64, sessionID string, startUTC, endUTC time.Time, category, description string, loc *time.Location) error {
+// for accuracy across DST, but dates are labeled in loc ('YYYY-MM-DD'), and each row
+// records loc's name. Rows are written within the caller's transaction.
+func sliceIntervalIntoDays(tx *sql.Tx, intervalID int64, sessionID string, startUTC, endUTC time.Time, category, description string, loc *time.Location, boundary time.Duration) error {
 	if !startUTC.Before(endUTC) {
 		// Zero or negative duration; still record presence on start day with 0?
 		// We'll skip inserting zero rows to avoid noise.
@@ -225,21 +610,20 @@ func sliceIntervalIntoDays(db *sql.DB, intervalID int64, sessionID string, start
 	startLocal := startUTC.In(loc)
 	endLocal := endUTC.In(loc)
 
-	// Compute the first midnight after startLocal
-	// Build boundary at start of next day
-	nextMidnight := time.Date(startLocal.Year(), startLocal.Month(), startLocal.Day()+1, 0, 0, 0, 0, loc)
-
-	tx, err := db.Begin()
-	if err != nil {
-		return err
+	// Days start at the boundary (wall clock, so DST days stay correct); time
+	// before it on a calendar date belongs to the previous day.
+	bh, bm := int(boundary/time.Hour), int(boundary%time.Hour/time.Minute)
+	day := time.Date(startLocal.Year(), startLocal.Month(), startLocal.Day(), 0, 0, 0, 0, loc)
+	if startLocal.Before(time.Date(day.Year(), day.Month(), day.Day(), bh, bm, 0, 0, loc)) {
+		day = day.AddDate(0, 0, -1)
 	}
-	defer tx.Rollback()
+	nextBoundary := time.Date(day.Year(), day.Month(), day.Day()+1, bh, bm, 0, 0, loc)
 
 	curStartLocal := startLocal
 	for curStartLocal.Before(endLocal) {
 		segmentEndLocal := endLocal
-		if nextMidnight.Before(endLocal) {
-			segmentEndLocal = nextMidnight
+		if nextBoundary.Before(endLocal) {
+			segmentEndLocal = nextBoundary
 		}
 
 		// Convert segment bounds to UTC for accurate duration seconds
@@ -250,25 +634,43 @@ func sliceIntervalIntoDays(db *sql.DB, intervalID int64, sessionID string, start
 			segDuration = 0
 		}
 
-		dateLocal := curStartLocal.Format("2006-01-02")
+		dateLocal := day.Format("2006-01-02")
 
 		if segDuration > 0 {
 			if _, err := tx.Exec(`
-INSERT INTO interval_days (interval_id, session_id, date_local, category, description, duration_seconds)
-VALUES (?, ?, ?, ?, ?, ?);`,
-				intervalID, sessionID, dateLocal, category, description, segDuration); err != nil {
+INSERT INTO interval_days (interval_id, session_id, date_local, category, description, duration_seconds, zone)
+VALUES (?, ?, ?, ?, ?, ?, ?);`,
+				intervalID, sessionID, dateLocal, category, description, segDuration, loc.String()); err != nil {
 				return fmt.Errorf("insert interval_day: %w", err)
 			}
 		}
 
 		// Advance to next segment
 		curStartLocal = segmentEndLocal
-		nextMidnight = time.Date(curStartLocal.Year(), curStartLocal.Month(), curStartLocal.Day()+1, 0, 0, 0, 0, loc)
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+		nextBoundary = time.Date(day.Year(), day.Month(), day.Day()+1, bh, bm, 0, 0, loc)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
+	return nil
+}
+
+// Vacuum rebuilds the database file to drop free pages left by deleted rows,
+// then lets SQLite refresh its query planner statistics.
+func Vacuum(db *sql.DB) error {
+	if _, err := db.Exec(`VACUUM;`); err != nil {
+		return fmt.Errorf("vacuum: %w", err)
+	}
+	if _, err := db.Exec(`PRAGMA optimize;`); err != nil {
+		return fmt.Errorf("optimize: %w", err)
 	}
 	return nil
 }
 
+// DatabaseSize returns the size in bytes of the database file at path.
+func DatabaseSize(path string) (int64, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), nil
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"database/sql"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// openTestDB returns a migrated in-memory database, closed when the test ends.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenAndMigrate(":memory:")
+	if err != nil {
+		t.Fatalf("OpenAndMigrate(:memory:): %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// dayTotals returns the live interval_days seconds per local date.
+func dayTotals(t *testing.T, db *sql.DB) map[string]int64 {
+	t.Helper()
+	rows, err := db.Query(`
+SELECT date_local, SUM(duration_seconds) FROM interval_days
+WHERE deleted_at IS NULL
+GROUP BY date_local;`)
+	if err != nil {
+		t.Fatalf("query interval_days: %v", err)
+	}
+	defer rows.Close()
+	totals := map[string]int64{}
+	for rows.Next() {
+		var date string
+		var secs int64
+		if err := rows.Scan(&date, &secs); err != nil {
+			t.Fatal(err)
+		}
+		totals[date] = secs
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return totals
+}
+
+func TestInMemoryStartStopCycle(t *testing.T) {
+	db := openTestDB(t)
+
+	// 23:00 to 01:30 local time, so the interval is sliced across midnight
+	start := time.Date(2026, 3, 2, 23, 0, 0, 0, time.Local)
+	end := start.Add(150 * time.Minute)
+	if err := InsertEvent(db, "s1", start.UTC(), "START", "Dev", "night shift", "", "", "test", true); err != nil {
+		t.Fatalf("insert START: %v", err)
+	}
+	if err := OpenInterval(db, "s1", 0, start.UTC(), "Dev", "night shift", "", "", true); err != nil {
+		t.Fatalf("open interval: %v", err)
+	}
+	if n := openIntervals(t, db, "s1"); n != 1 {
+		t.Fatalf("%d open intervals while running, want 1", n)
+	}
+
+	if err := CloseOpenIntervalAndSliceDays(db, "s1", start.UTC(), end.UTC(), "Dev", "night shift"); err != nil {
+		t.Fatalf("close interval: %v", err)
+	}
+	if err := InsertEvent(db, "s1", end.UTC(), "STOP", "Dev", "night shift", "", "", "test", true); err != nil {
+		t.Fatalf("insert STOP: %v", err)
+	}
+	if n := openIntervals(t, db, "s1"); n != 0 {
+		t.Errorf("%d open intervals after stop, want 0", n)
+	}
+
+	var duration int64
+	if err := db.QueryRow(`SELECT duration_seconds FROM intervals WHERE session_id = 's1';`).Scan(&duration); err != nil {
+		t.Fatal(err)
+	}
+	if duration != 150*60 {
+		t.Errorf("interval duration = %d, want %d", duration, 150*60)
+	}
+	want := map[string]int64{"2026-03-02": 60 * 60, "2026-03-03": 90 * 60}
+	if got := dayTotals(t, db); !maps.Equal(got, want) {
+		t.Errorf("day totals = %v, want %v", got, want)
+	}
+}
+
+// openIntervals returns how many of the session's intervals are still open.
+func openIntervals(t *testing.T, db *sql.DB, sessionID string) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM intervals WHERE session_id = ? AND end_utc IS NULL;`, sessionID).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// insertSession records a stopped single-interval session directly.
+func insertSession(t *testing.T, db *sql.DB, sessionID string, start, end time.Time) {
+	t.Helper()
+	if err := InsertEvent(db, sessionID, start, "START", "Dev", sessionID, "", "", "test", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := OpenInterval(db, sessionID, 0, start, "Dev", sessionID, "", "", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := CloseOpenIntervalAndSliceDays(db, sessionID, start, end, "Dev", sessionID); err != nil {
+		t.Fatal(err)
+	}
+	if err := InsertEvent(db, sessionID, end, "STOP", "Dev", sessionID, "", "", "test", true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// trashedDaySeconds returns the total seconds of trashed interval_days rows.
+func trashedDaySeconds(t *testing.T, db *sql.DB) int64 {
+	t.Helper()
+	var secs int64
+	if err := db.QueryRow(`
+SELECT COALESCE(SUM(duration_seconds), 0) FROM interval_days WHERE deleted_at IS NOT NULL;`).Scan(&secs); err != nil {
+		t.Fatal(err)
+	}
+	return secs
+}
+
+func TestRebuildIntervalDaysKeepsTotals(t *testing.T) {
+	db := openTestDB(t)
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	sessions := []struct {
+		id         string
+		start, end time.Time
+	}{
+		{"morning", day.Add(9 * time.Hour), day.Add(11*time.Hour + 15*time.Minute)},
+		{"overnight", day.Add(22 * time.Hour), day.Add(26 * time.Hour)},
+		{"trashed", day.Add(13 * time.Hour), day.Add(14 * time.Hour)},
+	}
+	for _, s := range sessions {
+		insertSession(t, db, s.id, s.start.UTC(), s.end.UTC())
+	}
+	if err := TrashSession(db, "trashed"); err != nil {
+		t.Fatalf("trash: %v", err)
+	}
+
+	wantTotals := dayTotals(t, db)
+	wantTrashed := trashedDaySeconds(t, db)
+	if wantTrashed != 3600 {
+		t.Fatalf("trashed seconds before rebuild = %d, want 3600", wantTrashed)
+	}
+
+	// Simulate a materialization that drifted out of sync
+	if _, err := db.Exec(`DELETE FROM interval_days WHERE session_id = 'overnight';`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`UPDATE interval_days SET duration_seconds = 1 WHERE session_id = 'morning';`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RebuildIntervalDays(db); err != nil {
+		t.Fatalf("RebuildIntervalDays: %v", err)
+	}
+	if got := dayTotals(t, db); !maps.Equal(got, wantTotals) {
+		t.Errorf("day totals after rebuild = %v, want %v", got, wantTotals)
+	}
+	if got := trashedDaySeconds(t, db); got != wantTrashed {
+		t.Errorf("trashed seconds after rebuild = %d, want %d", got, wantTrashed)
+	}
+}
+
+func TestOpenReadOnlyRejectsWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracker.db")
+	rw, err := OpenAndMigrate(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetSetting(rw, "scale", "1.5"); err != nil {
+		t.Fatal(err)
+	}
+	rw.Close()
+
+	db, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer db.Close()
+
+	if got := GetSetting(db, "scale", ""); got != "1.5" {
+		t.Errorf("read through read-only handle = %q, want %q", got, "1.5")
+	}
+	if err := SetSetting(db, "scale", "2.0"); err == nil {
+		t.Error("SetSetting on a read-only handle succeeded, want an error")
+	}
+	if err := InsertEvent(db, "s1", time.Now().UTC(), "START", "Dev", "", "", "", "test", true); err == nil {
+		t.Error("InsertEvent on a read-only handle succeeded, want an error")
+	}
+	if got := GetSetting(db, "scale", ""); got != "1.5" {
+		t.Errorf("setting after rejected write = %q, want %q", got, "1.5")
+	}
+}
+
+func TestOpenReadOnlyMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.db")
+	if db, err := OpenReadOnly(path); err == nil {
+		db.Close()
+		t.Fatal("OpenReadOnly on a missing file succeeded, want an error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("OpenReadOnly created %s", path)
+	}
+}
+
+func TestReportDate(t *testing.T) {
+	db := openTestDB(t)
+	for key, value := range map[string]string{"report_timezone": "UTC", "day_boundary": "04:00"} {
+		if err := SetSetting(db, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tc := range []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Date(2026, 3, 2, 3, 59, 0, 0, time.UTC), "2026-03-01"},
+		{time.Date(2026, 3, 2, 4, 0, 0, 0, time.UTC), "2026-03-02"},
+		{time.Date(2026, 3, 2, 23, 0, 0, 0, time.UTC), "2026-03-02"},
+	} {
+		if got := ReportDate(db, tc.at); got != tc.want {
+			t.Errorf("ReportDate(%v) = %s, want %s", tc.at, got, tc.want)
+		}
+	}
+}
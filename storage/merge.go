@@ -0,0 +1,268 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// MergeDatabase copies completed sessions from the Timeclock database at srcPath
+// into dst: their events, intervals, and interval_days. Interval ids are remapped
+// so the copied interval_days point at the new rows.
+//
+// A session is skipped when its session_id already exists in dst, or when it is
+// not finished in the source (no final STOP, or an interval still open), since
+// importing a running session would make it look running here too.
+// overlapping counts sessions with an interval that overlaps time already
+// recorded in dst (see FindOverlaps); with skipOverlaps they are skipped too,
+// otherwise they are merged so the caller can warn about double counting.
+// The source is opened with OpenReadOnly, so it is never migrated or modified.
+func MergeDatabase(dst *sql.DB, srcPath string, skipOverlaps bool) (merged, skipped, overlapping int, err error) {
+	src, err := OpenReadOnly(srcPath)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("open source: %w", err)
+	}
+	defer src.Close()
+
+	var srcVersion int
+	if err := src.QueryRow(`PRAGMA user_version;`).Scan(&srcVersion); err != nil {
+		return 0, 0, 0, fmt.Errorf("read source user_version: %w", err)
+	}
+	if srcVersion < 1 {
+		return 0, 0, 0, fmt.Errorf("%s is not a Timeclock database", srcPath)
+	}
+
+	sessionIDs, err := mergeableSessions(src, srcVersion)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	tx, err := dst.Begin()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	defer tx.Rollback()
+
+	for _, s := range sessionIDs {
+		if !s.complete {
+			skipped++
+			continue
+		}
+		var exists int
+		if err := tx.QueryRow(`
+SELECT EXISTS (SELECT 1 FROM events WHERE session_id = ?)
+    OR EXISTS (SELECT 1 FROM intervals WHERE session_id = ?);
+`, s.id, s.id).Scan(&exists); err != nil {
+			return 0, 0, 0, fmt.Errorf("check session %s: %w", s.id, err)
+		}
+		if exists != 0 {
+			skipped++
+			continue
+		}
+		overlaps, err := sessionOverlaps(src, tx, s.id)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("check session %s: %w", s.id, err)
+		}
+		if overlaps {
+			overlapping++
+			if skipOverlaps {
+				skipped++
+				continue
+			}
+		}
+		if err := copySession(src, srcVersion, tx, s.id); err != nil {
+			return 0, 0, 0, fmt.Errorf("copy session %s: %w", s.id, err)
+		}
+		merged++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, 0, fmt.Errorf("commit merge: %w", err)
+	}
+	return merged, skipped, overlapping, nil
+}
+
+// sessionOverlaps reports whether any interval of the source session overlaps
+// an interval already in dst.
+func sessionOverlaps(src *sql.DB, dst *sql.Tx, sessionID string) (bool, error) {
+	rows, err := src.Query(`SELECT start_utc, end_utc FROM intervals WHERE session_id = ? AND end_utc IS NOT NULL;`, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("read intervals: %w", err)
+	}
+	defer rows.Close()
+	var spans [][2]int64
+	for rows.Next() {
+		var span [2]int64
+		if err := rows.Scan(&span[0], &span[1]); err != nil {
+			return false, err
+		}
+		spans = append(spans, span)
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	for _, span := range spans {
+		found, err := findOverlaps(dst, time.Unix(span[0], 0), time.Unix(span[1], 0))
+		if err != nil {
+			return false, err
+		}
+		if len(found) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type mergeSession struct {
+	id       string
+	complete bool // last event is STOP and no interval is open
+}
+
+// mergeableSessions lists every session in src and whether it is complete.
+// Sessions in the source's trash are left out.
+func mergeableSessions(src *sql.DB, srcVersion int) ([]mergeSession, error) {
+	notTrashed := ""
+	if srcVersion >= 7 {
+		notTrashed = " WHERE deleted_at IS NULL"
+	}
+	rows, err := src.Query(`
+SELECT s.session_id,
+       (SELECT action FROM events WHERE session_id = s.session_id ORDER BY id DESC LIMIT 1) = 'STOP'
+       AND NOT EXISTS (SELECT 1 FROM intervals WHERE session_id = s.session_id AND end_utc IS NULL)
+FROM (SELECT DISTINCT session_id FROM events` + notTrashed + `) s
+ORDER BY s.session_id;
+`)
+	if err != nil {
+		return nil, fmt.Errorf("list source sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var res []mergeSession
+	for rows.Next() {
+		var s mergeSession
+		if err := rows.Scan(&s.id, &s.complete); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+// copySession copies one session's rows from src into tx, remapping interval ids.
+// srcVersion is the source's schema version, for columns it may not have yet.
+func copySession(src *sql.DB, srcVersion int, tx *sql.Tx, sessionID string) error {
+	issueColumn := "NULL"
+	if srcVersion >= 6 {
+		issueColumn = "issue_id"
+	}
+	versionColumn := "NULL"
+	if srcVersion >= 8 {
+		versionColumn = "app_version"
+	}
+	billableColumn := "1"
+	if srcVersion >= 9 {
+		billableColumn = "billable"
+	}
+	labelColumn := "NULL"
+	if srcVersion >= 10 {
+		labelColumn = "label"
+	}
+
+	// Events
+	evRows, err := src.Query(`
+SELECT timestamp_utc, action, category, description, user_tz, `+issueColumn+`, `+labelColumn+`, `+versionColumn+`, `+billableColumn+`
+FROM events WHERE session_id = ? ORDER BY id;
+`, sessionID)
+	if err != nil {
+		return fmt.Errorf("read events: %w", err)
+	}
+	defer evRows.Close()
+	for evRows.Next() {
+		var ts int64
+		var action, category string
+		var description, userTZ, issueID, label, appVersion sql.NullString
+		var billable bool
+		if err := evRows.Scan(&ts, &action, &category, &description, &userTZ, &issueID, &label, &appVersion, &billable); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`
+INSERT INTO events (session_id, timestamp_utc, action, category, description, user_tz, issue_id, label, app_version, billable)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+`, sessionID, ts, action, category, description, userTZ, issueID, label, appVersion, billable); err != nil {
+			return fmt.Errorf("insert event: %w", err)
+		}
+	}
+	if err := evRows.Err(); err != nil {
+		return err
+	}
+
+	// Intervals, remembering old id -> new id
+	ivRows, err := src.Query(`
+SELECT id, interval_index, start_utc, end_utc, category, description, duration_seconds, `+issueColumn+`, `+labelColumn+`, `+billableColumn+`
+FROM intervals WHERE session_id = ? ORDER BY id;
+`, sessionID)
+	if err != nil {
+		return fmt.Errorf("read intervals: %w", err)
+	}
+	defer ivRows.Close()
+	idMap := map[int64]int64{}
+	for ivRows.Next() {
+		var oldID, startUTC int64
+		var intervalIndex int
+		var endUTC, durationSeconds sql.NullInt64
+		var category string
+		var description, issueID, label sql.NullString
+		var billable bool
+		if err := ivRows.Scan(&oldID, &intervalIndex, &startUTC, &endUTC, &category, &description, &durationSeconds, &issueID, &label, &billable); err != nil {
+			return err
+		}
+		res, err := tx.Exec(`
+INSERT INTO intervals (session_id, interval_index, start_utc, end_utc, category, description, duration_seconds, issue_id, label, billable)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+`, sessionID, intervalIndex, startUTC, endUTC, category, description, durationSeconds, issueID, label, billable)
+		if err != nil {
+			return fmt.Errorf("insert interval: %w", err)
+		}
+		newID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		idMap[oldID] = newID
+	}
+	if err := ivRows.Err(); err != nil {
+		return err
+	}
+
+	// Day slices, pointed at the new interval rows
+	zoneColumn := "NULL"
+	if srcVersion >= 5 {
+		zoneColumn = "zone"
+	}
+	dayRows, err := src.Query(`
+SELECT interval_id, date_local, category, description, duration_seconds, `+zoneColumn+`
+FROM interval_days WHERE session_id = ? ORDER BY id;
+`, sessionID)
+	if err != nil {
+		return fmt.Errorf("read interval_days: %w", err)
+	}
+	defer dayRows.Close()
+	for dayRows.Next() {
+		var oldIntervalID, durationSeconds int64
+		var dateLocal, category string
+		var description, zone sql.NullString
+		if err := dayRows.Scan(&oldIntervalID, &dateLocal, &category, &description, &durationSeconds, &zone); err != nil {
+			return err
+		}
+		newIntervalID, ok := idMap[oldIntervalID]
+		if !ok {
+			continue // orphaned slice in the source
+		}
+		if _, err := tx.Exec(`
+INSERT INTO interval_days (interval_id, session_id, date_local, category, description, duration_seconds, zone)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+`, newIntervalID, sessionID, dateLocal, category, description, durationSeconds, zone); err != nil {
+			return fmt.Errorf("insert interval_day: %w", err)
+		}
+	}
+	return dayRows.Err()
+}
@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/1kaius1/Timeclock/logging"
+)
+
+// LatestSchemaVersion is the newest user_version this build understands. It
+// must equal len(migrations), which the tests check; bump it whenever a
+// migration is appended.
+const LatestSchemaVersion = 10
+
+// ErrSchemaTooNew means the database was written by a newer Timeclock.
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of Timeclock; please upgrade")
+
+// migrations upgrade the schema one version at a time: migrations[i] takes a
+// database from user_version i to i+1. Append new steps; never reorder or edit
+// steps that have shipped.
+var migrations = []func(*sql.Tx) error{
+	migrateV1,  // events, intervals, interval_days
+	migrateV2,  // settings
+	migrateV3,  // intervals.last_seen_utc
+	migrateV4,  // events.reason
+	migrateV5,  // interval_days.zone
+	migrateV6,  // events.issue_id, intervals.issue_id
+	migrateV7,  // deleted_at on events, intervals, interval_days
+	migrateV8,  // events.app_version
+	migrateV9,  // events.billable, intervals.billable
+	migrateV10, // events.label, intervals.label
+}
+
+// migrate applies every missing migration step, each in its own transaction,
+// bumping PRAGMA user_version after each so a failure leaves a consistent version.
+func migrate(db *sql.DB) error {
+	// Read current version
+	userVersion, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if userVersion > LatestSchemaVersion {
+		return fmt.Errorf("%w (database version %d, supported up to %d)", ErrSchemaTooNew, userVersion, LatestSchemaVersion)
+	}
+
+	for v := userVersion; v < len(migrations); v++ {
+		if err := applyMigration(db, v+1, migrations[v]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the database's PRAGMA user_version.
+func SchemaVersion(db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version;`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read user_version: %w", err)
+	}
+	return version, nil
+}
+
+func applyMigration(db *sql.DB, version int, step func(*sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := step(tx); err != nil {
+		return err
+	}
+
+	// PRAGMA arguments cannot be bound as parameters.
+	if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d;`, version)); err != nil {
+		return fmt.Errorf("set user_version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit migration v%d: %w", version, err)
+	}
+	logging.Infof("migrated database schema to v%d", version)
+	return nil
+}
+
+// Version 1: create events, intervals, interval_days
+func migrateV1(tx *sql.Tx) error {
+	// Event log: ground truth audit
+	if _, err := tx.Exec(`
+CREATE TABLE IF NOT EXISTS events (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id     TEXT NOT NULL,
+    timestamp_utc  INTEGER NOT NULL, -- epoch seconds
+    action         TEXT NOT NULL CHECK (action IN ('START','PAUSE','RESUME','STOP')),
+    category       TEXT NOT NULL,
+    description    TEXT,
+    user_tz        TEXT
+);`); err != nil {
+		return fmt.Errorf("create events: %w", err)
+	}
+
+	// Intervals: open/close slices
+	if _, err := tx.Exec(`
+CREATE TABLE IF NOT EXISTS intervals (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id       TEXT NOT NULL,
+    interval_index   INTEGER NOT NULL,
+    start_utc        INTEGER NOT NULL,
+    end_utc          INTEGER,            -- NULL until closed
+    category         TEXT NOT NULL,
+    description      TEXT,
+    duration_seconds INTEGER             -- set when closed
+);`); err != nil {
+		return fmt.Errorf("create intervals: %w", err)
+	}
+
+	// Daily materialization: fast reporting by day/week/month
+	if _, err := tx.Exec(`
+CREATE TABLE IF NOT EXISTS interval_days (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    interval_id      INTEGER NOT NULL,
+    session_id       TEXT NOT NULL,
+    date_local       TEXT NOT NULL,      -- 'YYYY-MM-DD'
+    category         TEXT NOT NULL,
+    description      TEXT,
+    duration_seconds INTEGER NOT NULL,
+    FOREIGN KEY (interval_id) REFERENCES intervals(id) ON DELETE CASCADE
+);`); err != nil {
+		return fmt.Errorf("create interval_days: %w", err)
+	}
+	return nil
+}
+
+// Version 2: create settings table
+func migrateV2(tx *sql.Tx) error {
+	if _, err := tx.Exec(`
+CREATE TABLE IF NOT EXISTS settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);`); err != nil {
+		return fmt.Errorf("create settings: %w", err)
+	}
+	return nil
+}
+
+// Version 3: checkpoint column for crash recovery of open intervals
+func migrateV3(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE intervals ADD COLUMN last_seen_utc INTEGER;`); err != nil {
+		return fmt.Errorf("add intervals.last_seen_utc: %w", err)
+	}
+	return nil
+}
+
+// Version 4: optional reason on events (e.g. why work was paused)
+func migrateV4(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE events ADD COLUMN reason TEXT;`); err != nil {
+		return fmt.Errorf("add events.reason: %w", err)
+	}
+	return nil
+}
+
+// Version 5: the zone each interval_days row was bucketed in. NULL means the
+// system local zone, which is how every earlier row was sliced.
+func migrateV5(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE interval_days ADD COLUMN zone TEXT;`); err != nil {
+		return fmt.Errorf("add interval_days.zone: %w", err)
+	}
+	return nil
+}
+
+// Version 6: optional ticket/issue id (e.g. a Jira key) on events and intervals
+func migrateV6(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE events ADD COLUMN issue_id TEXT;`); err != nil {
+		return fmt.Errorf("add events.issue_id: %w", err)
+	}
+	if _, err := tx.Exec(`ALTER TABLE intervals ADD COLUMN issue_id TEXT;`); err != nil {
+		return fmt.Errorf("add intervals.issue_id: %w", err)
+	}
+	return nil
+}
+
+// Version 7: soft delete. Rows moved to the trash get deleted_at (epoch seconds)
+// and are left out of reports until restored or purged.
+func migrateV7(tx *sql.Tx) error {
+	for _, table := range []string{"events", "intervals", "interval_days"} {
+		if _, err := tx.Exec(`ALTER TABLE ` + table + ` ADD COLUMN deleted_at INTEGER;`); err != nil {
+			return fmt.Errorf("add %s.deleted_at: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// Version 8: the Timeclock version that wrote each event, for tracing data
+// issues to a release. Older rows stay NULL.
+func migrateV8(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE events ADD COLUMN app_version TEXT;`); err != nil {
+		return fmt.Errorf("add events.app_version: %w", err)
+	}
+	return nil
+}
+
+// Version 9: billable flag on events and intervals, set per session. Existing
+// rows count as billable.
+func migrateV9(tx *sql.Tx) error {
+	for _, table := range []string{"events", "intervals"} {
+		if _, err := tx.Exec(`ALTER TABLE ` + table + ` ADD COLUMN billable INTEGER NOT NULL DEFAULT 1;`); err != nil {
+			return fmt.Errorf("add %s.billable: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// Version 10: optional session label (e.g. "Morning block") on events and
+// intervals, a grouping above category and description
+func migrateV10(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE events ADD COLUMN label TEXT;`); err != nil {
+		return fmt.Errorf("add events.label: %w", err)
+	}
+	if _, err := tx.Exec(`ALTER TABLE intervals ADD COLUMN label TEXT;`); err != nil {
+		return fmt.Errorf("add intervals.label: %w", err)
+	}
+	return nil
+}
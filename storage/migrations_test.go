@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"testing"
+)
+
+// openRawDB opens a database file without migrating it.
+func openRawDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// userVersion returns db's PRAGMA user_version.
+func userVersion(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version;`).Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+// schemaOf returns the CREATE statements of db's tables and indexes by name.
+func schemaOf(t *testing.T, db *sql.DB) map[string]string {
+	t.Helper()
+	rows, err := db.Query(`SELECT name, sql FROM sqlite_master WHERE sql IS NOT NULL;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	schema := map[string]string{}
+	for rows.Next() {
+		var name, sql string
+		if err := rows.Scan(&name, &sql); err != nil {
+			t.Fatal(err)
+		}
+		schema[name] = sql
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+// assertLatestSchema checks that db is at the latest version and has the same
+// tables and columns as a freshly created database.
+func assertLatestSchema(t *testing.T, db *sql.DB) {
+	t.Helper()
+	if version := userVersion(t, db); version != len(migrations) {
+		t.Errorf("user_version = %d, want %d", version, len(migrations))
+	}
+	want := schemaOf(t, openTestDB(t))
+	if got := schemaOf(t, db); !maps.Equal(got, want) {
+		t.Errorf("schema = %v\nwant %v", got, want)
+	}
+}
+
+func TestMigrateFromV0(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "v0.db")
+	// A file SQLite has created but nothing has been written to is version 0
+	raw := openRawDB(t, path)
+	if _, err := raw.Exec(`PRAGMA user_version = 0;`); err != nil {
+		t.Fatal(err)
+	}
+	raw.Close()
+
+	db, err := OpenAndMigrate(path)
+	if err != nil {
+		t.Fatalf("OpenAndMigrate: %v", err)
+	}
+	defer db.Close()
+	assertLatestSchema(t, db)
+}
+
+func TestMigratePartiallyMigrated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partial.db")
+	raw := openRawDB(t, path)
+	const applied = 3
+	for v := 0; v < applied; v++ {
+		if err := applyMigration(raw, v+1, migrations[v]); err != nil {
+			t.Fatalf("apply v%d: %v", v+1, err)
+		}
+	}
+	// Data written by the older schema must survive the remaining steps
+	if _, err := raw.Exec(`
+INSERT INTO events (session_id, timestamp_utc, action, category, description, user_tz)
+VALUES ('old', 1700000000, 'START', 'Dev', 'before upgrade', 'UTC');`); err != nil {
+		t.Fatal(err)
+	}
+	raw.Close()
+
+	db, err := OpenAndMigrate(path)
+	if err != nil {
+		t.Fatalf("OpenAndMigrate: %v", err)
+	}
+	defer db.Close()
+	assertLatestSchema(t, db)
+
+	var description string
+	if err := db.QueryRow(`SELECT description FROM events WHERE session_id = 'old';`).Scan(&description); err != nil {
+		t.Fatalf("event written at v%d: %v", applied, err)
+	}
+	if description != "before upgrade" {
+		t.Errorf("description = %q, want %q", description, "before upgrade")
+	}
+
+	// Migrating an up-to-date database again is a no-op
+	if err := migrate(db); err != nil {
+		t.Errorf("second migrate: %v", err)
+	}
+	assertLatestSchema(t, db)
+}
+
+func TestLatestSchemaVersionMatchesMigrations(t *testing.T) {
+	if LatestSchemaVersion != len(migrations) {
+		t.Fatalf("LatestSchemaVersion = %d but there are %d migrations; bump it when appending a migration",
+			LatestSchemaVersion, len(migrations))
+	}
+}
+
+func TestOpenFutureSchemaVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "future.db")
+	raw := openRawDB(t, path)
+	future := LatestSchemaVersion + 1
+	if _, err := raw.Exec(fmt.Sprintf(`PRAGMA user_version = %d;`, future)); err != nil {
+		t.Fatal(err)
+	}
+	raw.Close()
+
+	db, err := OpenAndMigrate(path)
+	if err == nil {
+		db.Close()
+		t.Fatal("OpenAndMigrate succeeded on a database from a newer version")
+	}
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("error = %v, want ErrSchemaTooNew", err)
+	}
+
+	// The database must be left as it was
+	raw = openRawDB(t, path)
+	if version := userVersion(t, raw); version != future {
+		t.Errorf("user_version after failed open = %d, want %d", version, future)
+	}
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// IntervalDetail is one interval of a session. EndUTC is zero while it is open.
+type IntervalDetail struct {
+	ID              int64
+	Index           int
+	StartUTC        time.Time
+	EndUTC          time.Time
+	DurationSeconds int64
+	Category        string
+	Description     string
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// FindOverlaps returns the intervals that share any time with [startUTC, endUTC),
+// ordered by start. A running interval counts as lasting until now. Intervals
+// that merely touch the range, and those in the trash, are not included.
+func FindOverlaps(db *sql.DB, startUTC, endUTC time.Time) ([]IntervalDetail, error) {
+	return findOverlaps(db, startUTC, endUTC)
+}
+
+func findOverlaps(q queryer, startUTC, endUTC time.Time) ([]IntervalDetail, error) {
+	rows, err := q.Query(`
+SELECT id, interval_index, start_utc, end_utc, COALESCE(duration_seconds, 0), category, COALESCE(description, '')
+FROM intervals
+WHERE deleted_at IS NULL AND start_utc < ? AND COALESCE(end_utc, ?) > ?
+ORDER BY start_utc, id;
+`, endUTC.Unix(), time.Now().Unix(), startUTC.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("query overlapping intervals: %w", err)
+	}
+	defer rows.Close()
+
+	var res []IntervalDetail
+	for rows.Next() {
+		var iv IntervalDetail
+		var start int64
+		var end sql.NullInt64
+		if err := rows.Scan(&iv.ID, &iv.Index, &start, &end, &iv.DurationSeconds, &iv.Category, &iv.Description); err != nil {
+			return nil, err
+		}
+		iv.StartUTC = time.Unix(start, 0).UTC()
+		if end.Valid {
+			iv.EndUTC = time.Unix(end.Int64, 0).UTC()
+		}
+		res = append(res, iv)
+	}
+	return res, rows.Err()
+}
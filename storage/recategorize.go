@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+)
+
+// recategorizeTargets lists the rows Recategorize touches, given the local range
+// as epoch bounds [from, toExclusive) and the category being replaced. Intervals
+// are selected by start time, and their interval_days follow them so an interval
+// crossing midnight keeps one category across both days.
+var recategorizeTargets = []struct{ table, where string }{
+	{"events", "timestamp_utc >= ? AND timestamp_utc < ? AND category = ?"},
+	{"intervals", "start_utc >= ? AND start_utc < ? AND category = ?"},
+	{"interval_days", `interval_id IN (
+    SELECT id FROM intervals WHERE start_utc >= ? AND start_utc < ? AND category = ?)`},
+}
+
+// CountRecategorize returns how many rows Recategorize would change for the same
+// arguments, so the caller can confirm before committing to it.
+func CountRecategorize(db *sql.DB, from, to, oldCategory string) (int, error) {
+	fromUnix, toUnix, err := localRangeUnix(db, from, to)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, t := range recategorizeTargets {
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM `+t.table+` WHERE `+t.where+`;`,
+			fromUnix, toUnix, oldCategory).Scan(&n); err != nil {
+			return 0, fmt.Errorf("count %s: %w", t.table, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// Recategorize renames oldCategory to newCategory in events, intervals, and
+// interval_days recorded on dates [from, to] inclusive ('YYYY-MM-DD', days as
+// interval_days slices them), in one transaction. It returns the number of
+// rows changed.
+func Recategorize(db *sql.DB, from, to, oldCategory, newCategory string) (affected int, err error) {
+	fromUnix, toUnix, err := localRangeUnix(db, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if oldCategory == newCategory {
+		return 0, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// interval_days first: its selection depends on the intervals' old category
+	for i := len(recategorizeTargets) - 1; i >= 0; i-- {
+		t := recategorizeTargets[i]
+		res, err := tx.Exec(`UPDATE `+t.table+` SET category = ? WHERE `+t.where+`;`,
+			newCategory, fromUnix, toUnix, oldCategory)
+		if err != nil {
+			return 0, fmt.Errorf("update %s: %w", t.table, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		affected += int(n)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// localRangeUnix converts an inclusive 'YYYY-MM-DD' date range to epoch seconds
+// [from, toExclusive), with days as interval_days slices them (see DayBounds).
+func localRangeUnix(db *sql.DB, from, to string) (int64, int64, error) {
+	loc, boundary := slicingSettings(db)
+	f, _, err := DayBounds(from, loc, boundary)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse from date: %w", err)
+	}
+	_, t, err := DayBounds(to, loc, boundary)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse to date: %w", err)
+	}
+	return f.Unix(), t.Unix(), nil
+}
+
+// renameCategoryTables are the tables RenameCategory rewrites.
+var renameCategoryTables = []string{"events", "intervals", "interval_days"}
+
+// Per-category settings are stored under one of these prefixes followed by the
+// category name; RenameCategory moves them to the new name.
+const (
+	CategoryGoalKeyPrefix  = "category_goal."
+	MonthlyBudgetKeyPrefix = "monthly_budget."
+	PayrollCodeKeyPrefix   = "payroll_code."
+)
+
+// categoryKeyPrefixes are the per-category setting prefixes RenameCategory moves.
+var categoryKeyPrefixes = []string{CategoryGoalKeyPrefix, MonthlyBudgetKeyPrefix, PayrollCodeKeyPrefix}
+
+// Settings naming categories that RenameCategory follows: single names, and
+// lists stored with EncodeCategoryList.
+var (
+	categoryNameSettings = []string{"auto_start_category", "report_category"}
+	categoryListSettings = []string{"quick_start_categories", "report_exclude_categories"}
+)
+
+// DefaultCategories are the categories offered before any has been renamed.
+var DefaultCategories = []string{"Task", "Project", "Meeting", "Training", "Mentoring", "Incident", "Major Incident"}
+
+// CategoryOptions returns the categories offered for new work: the
+// "categories" setting, or DefaultCategories until a rename has stored one.
+func CategoryOptions(db *sql.DB) []string {
+	if categories := DecodeCategoryList(GetSetting(db, "categories", "")); len(categories) > 0 {
+		return categories
+	}
+	return append([]string(nil), DefaultCategories...)
+}
+
+// CountCategoryRows returns how many rows RenameCategory would change for
+// category, so the caller can confirm before committing to it.
+func CountCategoryRows(db *sql.DB, category string) (int, error) {
+	total := 0
+	for _, table := range renameCategoryTables {
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM `+table+` WHERE category = ?;`, category).Scan(&n); err != nil {
+			return 0, fmt.Errorf("count %s: %w", table, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// RenameCategory renames oldName to newName across all recorded history (events,
+// intervals, and interval_days, trashed rows included) in one transaction and
+// returns the number of rows changed. If newName is already in use the two
+// categories are merged.
+//
+// The category's settings follow it in the same transaction: its goal, budget
+// and payroll code (kept as they are if newName already has its own), the
+// settings naming it, and the category options (see CategoryOptions), where
+// newName takes oldName's place or is added.
+func RenameCategory(db *sql.DB, oldName, newName string) (affected int, err error) {
+	if oldName == "" || newName == "" {
+		return 0, fmt.Errorf("category names must not be empty")
+	}
+	if oldName == newName {
+		return 0, nil
+	}
+	options := CategoryOptions(db)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, table := range renameCategoryTables {
+		res, err := tx.Exec(`UPDATE `+table+` SET category = ? WHERE category = ?;`, newName, oldName)
+		if err != nil {
+			return 0, fmt.Errorf("update %s: %w", table, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		affected += int(n)
+	}
+	if err := renameCategorySettings(tx, oldName, newName, options); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// renameCategorySettings is the settings half of RenameCategory. options is
+// the current CategoryOptions, read before the transaction began.
+func renameCategorySettings(tx *sql.Tx, oldName, newName string, options []string) error {
+	for _, prefix := range categoryKeyPrefixes {
+		// A value newName already has is kept; an empty one means none
+		if _, err := tx.Exec(`
+INSERT INTO settings (key, value)
+SELECT ?, value FROM settings WHERE key = ? AND value <> ''
+ON CONFLICT(key) DO UPDATE SET value = excluded.value WHERE settings.value = '';
+`, prefix+newName, prefix+oldName); err != nil {
+			return fmt.Errorf("move setting %s%s: %w", prefix, oldName, err)
+		}
+		if _, err := tx.Exec(`DELETE FROM settings WHERE key = ?;`, prefix+oldName); err != nil {
+			return fmt.Errorf("move setting %s%s: %w", prefix, oldName, err)
+		}
+	}
+	for _, key := range categoryNameSettings {
+		if _, err := tx.Exec(`UPDATE settings SET value = ? WHERE key = ? AND value = ?;`, newName, key, oldName); err != nil {
+			return fmt.Errorf("update setting %s: %w", key, err)
+		}
+	}
+	for _, key := range categoryListSettings {
+		var value string
+		err := tx.QueryRow(`SELECT value FROM settings WHERE key = ?;`, key).Scan(&value)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("read setting %s: %w", key, err)
+		}
+		list := DecodeCategoryList(value)
+		if !slices.Contains(list, oldName) {
+			continue
+		}
+		if err := upsertSetting(tx, key, EncodeCategoryList(renameInList(list, oldName, newName))); err != nil {
+			return err
+		}
+	}
+	if !slices.Contains(options, oldName) {
+		options = append(options, oldName) // so newName is added below
+	}
+	return upsertSetting(tx, "categories", EncodeCategoryList(renameInList(options, oldName, newName)))
+}
+
+// renameInList replaces oldName with newName in list, dropping it instead if
+// newName is already there.
+func renameInList(list []string, oldName, newName string) []string {
+	merging := slices.Contains(list, newName)
+	res := make([]string, 0, len(list))
+	for _, c := range list {
+		switch {
+		case c != oldName:
+			res = append(res, c)
+		case !merging:
+			res = append(res, newName)
+		}
+	}
+	return res
+}
+
+// upsertSetting is SetSetting within a transaction.
+func upsertSetting(tx *sql.Tx, key, value string) error {
+	if _, err := tx.Exec(`
+INSERT INTO settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value;
+`, key, value); err != nil {
+		return fmt.Errorf("store setting %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRenameCategoryMovesSettings(t *testing.T) {
+	db := openTestDB(t)
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local).UTC()
+	insertSession(t, db, "s1", start, start.Add(time.Hour))
+
+	for k, v := range map[string]string{
+		CategoryGoalKeyPrefix + "Dev":  "min 2h",
+		MonthlyBudgetKeyPrefix + "Dev": "40h",
+		PayrollCodeKeyPrefix + "Dev":   "P-1",
+		PayrollCodeKeyPrefix + "Eng":   "P-2", // Eng keeps its own code
+		"auto_start_category":          "Dev",
+		"report_category":              "Meeting",
+		"quick_start_categories":       EncodeCategoryList([]string{"Dev", "Meeting"}),
+		"report_exclude_categories":    EncodeCategoryList([]string{"Dev, internal"}),
+	} {
+		if err := SetSetting(db, k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := RenameCategory(db, "Dev", "Eng"); err != nil {
+		t.Fatalf("RenameCategory: %v", err)
+	}
+
+	for k, want := range map[string]string{
+		CategoryGoalKeyPrefix + "Eng":  "min 2h",
+		MonthlyBudgetKeyPrefix + "Eng": "40h",
+		PayrollCodeKeyPrefix + "Eng":   "P-2",
+		CategoryGoalKeyPrefix + "Dev":  "(none)",
+		MonthlyBudgetKeyPrefix + "Dev": "(none)",
+		PayrollCodeKeyPrefix + "Dev":   "(none)",
+		"auto_start_category":          "Eng",
+		"report_category":              "Meeting",
+		"quick_start_categories":       EncodeCategoryList([]string{"Eng", "Meeting"}),
+		"report_exclude_categories":    EncodeCategoryList([]string{"Dev, internal"}),
+	} {
+		if got := GetSetting(db, k, "(none)"); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+	if options := CategoryOptions(db); !slices.Contains(options, "Eng") || slices.Contains(options, "Dev") {
+		t.Errorf("CategoryOptions = %q, want Eng offered and Dev not", options)
+	}
+
+	// Renaming an offered category replaces it in place
+	if _, err := RenameCategory(db, "Task", "Tasks"); err != nil {
+		t.Fatalf("RenameCategory: %v", err)
+	}
+	if options := CategoryOptions(db); options[0] != "Tasks" || slices.Contains(options, "Task") {
+		t.Errorf("CategoryOptions = %q, want Tasks first and no Task", options)
+	}
+}
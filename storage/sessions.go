@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SessionInfo describes a session by its latest event and interval.
+type SessionInfo struct {
+	ID           string
+	Category     string
+	Description  string
+	IssueID      string
+	Label        string
+	Billable     bool
+	LastAction   string    // action of the session's latest event
+	LastEventUTC time.Time // when that event happened
+	LastIndex    int       // highest interval_index used so far, -1 if none
+	HasOpen      bool      // an interval is still open
+}
+
+// Stopped reports whether the session has been finished with STOP and has no
+// open interval.
+func (s SessionInfo) Stopped() bool {
+	return s.LastAction == "STOP" && !s.HasOpen
+}
+
+// sessionInfoQuery selects SessionInfo columns for sessions from events e, the
+// session's latest event.
+const sessionInfoQuery = `
+SELECT e.session_id, e.category, COALESCE(e.description, ''), COALESCE(e.issue_id, ''), COALESCE(e.label, ''), e.billable, e.action, e.timestamp_utc,
+       COALESCE((SELECT MAX(interval_index) FROM intervals WHERE session_id = e.session_id), -1),
+       EXISTS (SELECT 1 FROM intervals WHERE session_id = e.session_id AND end_utc IS NULL)
+FROM events e
+WHERE e.id = (SELECT MAX(id) FROM events WHERE session_id = e.session_id)
+  AND e.deleted_at IS NULL
+`
+
+func scanSessionInfo(row interface{ Scan(...any) error }) (SessionInfo, error) {
+	var s SessionInfo
+	var ts int64
+	var hasOpen int
+	if err := row.Scan(&s.ID, &s.Category, &s.Description, &s.IssueID, &s.Label, &s.Billable, &s.LastAction, &ts, &s.LastIndex, &hasOpen); err != nil {
+		return s, err
+	}
+	s.LastEventUTC = time.Unix(ts, 0).UTC()
+	s.HasOpen = hasOpen != 0
+	return s, nil
+}
+
+// GetSession returns the session with the given id, or sql.ErrNoRows.
+func GetSession(db *sql.DB, sessionID string) (SessionInfo, error) {
+	return scanSessionInfo(db.QueryRow(sessionInfoQuery+`AND e.session_id = ?;`, sessionID))
+}
+
+// RecentSessions returns up to limit sessions, most recently active first.
+func RecentSessions(db *sql.DB, limit int) ([]SessionInfo, error) {
+	rows, err := db.Query(sessionInfoQuery+`ORDER BY e.id DESC LIMIT ?;`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query recent sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var res []SessionInfo
+	for rows.Next() {
+		s, err := scanSessionInfo(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
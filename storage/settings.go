@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// machineSettings describe this installation's runtime state rather than user
+// preferences, so they are neither exported nor imported.
+var machineSettings = map[string]bool{
+	"clean_shutdown":            true,
+	"description_draft":         true,
+	"auto_pause_snoozed_date":   true,
+	"export_schedule_last_week": true,
+}
+
+// numericSettings bounds the known numeric settings; imports outside them are rejected.
+var numericSettings = map[string]struct{ min, max float64 }{
+	"scale":                       {0.5, 3.0},
+	"merge_gap_minutes":           {0, 1 << 31},
+	"min_interval_seconds":        {0, 1 << 31},
+	"report_auto_refresh_seconds": {5, 1 << 31},
+	"recent_description_length":   {4, 1 << 31},
+	"min_stop_seconds":            {0, 1 << 31},
+	"stale_restore_hours":         {0, 1 << 31},
+}
+
+// durationSettings are the known duration settings, validated on import with
+// the caller's parser. Optional ones may be empty, meaning none; the others
+// must be positive.
+var durationSettings = map[string]struct{ optional bool }{
+	"weekly_goal":        {optional: true},
+	"daily_goal":         {optional: true},
+	"interval_target":    {optional: true},
+	"break_reminder":     {optional: true},
+	"overtime_threshold": {},
+	"focus_threshold":    {},
+	"billing_round_to":   {},
+	"auto_resume_window": {},
+}
+
+// ExportSettings writes every preference in the settings table to w as a JSON
+// object of key/value strings.
+func ExportSettings(db *sql.DB, w io.Writer) error {
+	rows, err := db.Query(`SELECT key, value FROM settings ORDER BY key;`)
+	if err != nil {
+		return fmt.Errorf("query settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := map[string]string{}
+	for rows.Next() {
+		var k, v string
+		if err := rows.Scan(&k, &v); err != nil {
+			return err
+		}
+		if !machineSettings[k] {
+			settings[k] = v
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(settings)
+}
+
+// ImportSettings reads a JSON object written by ExportSettings and upserts its
+// keys, leaving settings it doesn't mention untouched. Known numeric, duration,
+// time-of-day and zone settings are validated first, durations with
+// parseDuration (the parser the settings are entered with); if any is invalid
+// nothing is applied.
+func ImportSettings(db *sql.DB, r io.Reader, parseDuration func(string) (time.Duration, error)) error {
+	var settings map[string]string
+	if err := json.NewDecoder(r).Decode(&settings); err != nil {
+		return fmt.Errorf("parse settings: %w", err)
+	}
+	for k, v := range settings {
+		if !validSetting(k, v, parseDuration) {
+			return fmt.Errorf("invalid value %q for setting %s", v, k)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for k, v := range settings {
+		if machineSettings[k] {
+			continue
+		}
+		if _, err := tx.Exec(`
+INSERT INTO settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value;
+`, k, v); err != nil {
+			return fmt.Errorf("store setting %s: %w", k, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// validSetting reports whether v is acceptable for the known setting k, as
+// ImportSettings checks it. Unknown settings are accepted as they are.
+func validSetting(k, v string, parseDuration func(string) (time.Duration, error)) bool {
+	if bounds, ok := numericSettings[k]; ok {
+		n, err := strconv.ParseFloat(v, 64)
+		return err == nil && n >= bounds.min && n <= bounds.max
+	}
+	if kind, ok := durationSettings[k]; ok {
+		if v == "" {
+			return kind.optional
+		}
+		d, err := parseDuration(v)
+		return err == nil && (d > 0 || kind.optional)
+	}
+	switch k {
+	case "day_boundary":
+		_, err := ParseDayBoundary(v)
+		return err == nil
+	case "report_timezone":
+		_, err := loadZone(v)
+		return err == nil
+	case "auto_pause_time":
+		// Empty disables it; otherwise compared against the clock as HH:MM
+		_, err := time.Parse("15:04", v)
+		return v == "" || err == nil
+	}
+	return true
+}
+
+// EncodeCategoryList stores a list of categories in a setting as a JSON array,
+// so names containing commas survive the round trip.
+func EncodeCategoryList(categories []string) string {
+	if len(categories) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(categories) // a []string always marshals
+	return string(b)
+}
+
+// DecodeCategoryList reads a setting written by EncodeCategoryList. Values
+// saved by earlier versions as a comma-separated list are still accepted.
+func DecodeCategoryList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var categories []string
+	if err := json.Unmarshal([]byte(s), &categories); err == nil {
+		return categories
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSettingsRoundTripSpecialCharacters exports a value that needs quoting and
+// imports it into a fresh database, checking it arrives unchanged.
+func TestSettingsRoundTripSpecialCharacters(t *testing.T) {
+	const value = "Fix parser, part 2\nSaid \"done\", then wasn't"
+	src := openTestDB(t)
+	if err := SetSetting(src, "auto_start_category", value); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := ExportSettings(src, &buf); err != nil {
+		t.Fatalf("ExportSettings: %v", err)
+	}
+
+	dst := openTestDB(t)
+	if err := ImportSettings(dst, &buf, time.ParseDuration); err != nil {
+		t.Fatalf("ImportSettings: %v", err)
+	}
+	if got := GetSetting(dst, "auto_start_category", ""); got != value {
+		t.Errorf("imported value = %q, want %q", got, value)
+	}
+}
+
+// TestImportSettingsValidation round-trips valid values of the validated
+// settings and checks that one bad value rejects the whole import.
+func TestImportSettingsValidation(t *testing.T) {
+	valid := map[string]string{
+		"min_stop_seconds":    "30",
+		"stale_restore_hours": "0",
+		"daily_goal":          "",
+		"weekly_goal":         "40h",
+		"overtime_threshold":  "8h",
+		"day_boundary":        "04:00",
+		"report_timezone":     "UTC",
+		"auto_pause_time":     "17:00",
+	}
+	src := openTestDB(t)
+	for k, v := range valid {
+		if err := SetSetting(src, k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Machine state is not carried over
+	if err := SetSetting(src, "description_draft", "half-typed"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := ExportSettings(src, &buf); err != nil {
+		t.Fatalf("ExportSettings: %v", err)
+	}
+	if strings.Contains(buf.String(), "description_draft") {
+		t.Errorf("export contains description_draft:\n%s", buf.String())
+	}
+	dst := openTestDB(t)
+	if err := ImportSettings(dst, &buf, time.ParseDuration); err != nil {
+		t.Fatalf("ImportSettings: %v", err)
+	}
+	for k, want := range valid {
+		if got := GetSetting(dst, k, "(none)"); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+
+	for k, v := range map[string]string{
+		"min_stop_seconds":    "-1",
+		"stale_restore_hours": "soon",
+		"overtime_threshold":  "",
+		"focus_threshold":     "0s",
+		"break_reminder":      "a while",
+		"day_boundary":        "25:00",
+		"report_timezone":     "Mars/Olympus_Mons",
+		"auto_pause_time":     "5pm",
+	} {
+		in := `{"daily_goal": "6h", "` + k + `": "` + v + `"}`
+		db := openTestDB(t)
+		if err := ImportSettings(db, strings.NewReader(in), time.ParseDuration); err == nil {
+			t.Errorf("ImportSettings accepted %s = %q", k, v)
+		}
+		if got := GetSetting(db, "daily_goal", "(none)"); got != "(none)" {
+			t.Errorf("after rejecting %s, daily_goal = %q; want nothing applied", k, got)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSessionNotStopped is returned when trashing a session that is still running
+// or paused; only finished sessions can be moved to the trash.
+var ErrSessionNotStopped = errors.New("only stopped sessions can be moved to the trash")
+
+// trashTables are the tables a session's rows are soft-deleted from, children last.
+var trashTables = []string{"events", "intervals", "interval_days"}
+
+// TrashedSession is a session in the trash.
+type TrashedSession struct {
+	ID           string
+	Category     string
+	Description  string
+	StartUTC     time.Time // first event
+	DeletedUTC   time.Time
+	TotalSeconds int64 // worked time of its closed intervals
+}
+
+// TrashSession soft-deletes every row of a stopped session by setting deleted_at,
+// so it drops out of reports and the recent list but can still be restored.
+func TrashSession(db *sql.DB, sessionID string) error {
+	info, err := GetSession(db, sessionID)
+	if err != nil {
+		return fmt.Errorf("find session: %w", err)
+	}
+	if !info.Stopped() {
+		return ErrSessionNotStopped
+	}
+	return setSessionDeletedAt(db, sessionID, time.Now().UTC().Unix())
+}
+
+// RestoreSession brings a trashed session back into reports.
+func RestoreSession(db *sql.DB, sessionID string) error {
+	return setSessionDeletedAt(db, sessionID, nil)
+}
+
+func setSessionDeletedAt(db *sql.DB, sessionID string, deletedAt any) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, table := range trashTables {
+		if _, err := tx.Exec(`UPDATE `+table+` SET deleted_at = ? WHERE session_id = ?;`, deletedAt, sessionID); err != nil {
+			return fmt.Errorf("update %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// PurgeSession permanently removes a trashed session. Sessions that are not in
+// the trash are left alone.
+func PurgeSession(db *sql.DB, sessionID string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i := len(trashTables) - 1; i >= 0; i-- {
+		if _, err := tx.Exec(`DELETE FROM `+trashTables[i]+` WHERE session_id = ? AND deleted_at IS NOT NULL;`, sessionID); err != nil {
+			return fmt.Errorf("purge %s: %w", trashTables[i], err)
+		}
+	}
+	return tx.Commit()
+}
+
+// TrashedSessions lists the sessions in the trash, most recently deleted first.
+func TrashedSessions(db *sql.DB) ([]TrashedSession, error) {
+	rows, err := db.Query(`
+SELECT e.session_id,
+       (SELECT category FROM events WHERE session_id = e.session_id ORDER BY id LIMIT 1),
+       COALESCE((SELECT description FROM events WHERE session_id = e.session_id ORDER BY id LIMIT 1), ''),
+       MIN(e.timestamp_utc), MAX(e.deleted_at),
+       COALESCE((SELECT SUM(duration_seconds) FROM intervals WHERE session_id = e.session_id AND end_utc IS NOT NULL), 0)
+FROM events e
+WHERE e.deleted_at IS NOT NULL
+GROUP BY e.session_id
+ORDER BY MAX(e.deleted_at) DESC, MIN(e.timestamp_utc) DESC;
+`)
+	if err != nil {
+		return nil, fmt.Errorf("query trash: %w", err)
+	}
+	defer rows.Close()
+
+	var res []TrashedSession
+	for rows.Next() {
+		var t TrashedSession
+		var start, deleted int64
+		if err := rows.Scan(&t.ID, &t.Category, &t.Description, &start, &deleted, &t.TotalSeconds); err != nil {
+			return nil, err
+		}
+		t.StartUTC = time.Unix(start, 0).UTC()
+		t.DeletedUTC = time.Unix(deleted, 0).UTC()
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
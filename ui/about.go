@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// showAboutDialog shows the version and environment details support usually
+// asks for. The text is selectable so it can be copied into a bug report.
+func showAboutDialog(w fyne.Window, state *domain.AppState, dbPath, appVersion string) {
+	schema := "unknown"
+	if v, err := storage.SchemaVersion(state.DB); err != nil {
+		notifyError(w, "About error", err)
+	} else {
+		schema = fmt.Sprintf("%d (this build supports up to %d)", v, storage.LatestSchemaVersion)
+	}
+
+	info := widget.NewMultiLineEntry()
+	info.SetText(fmt.Sprintf("Timeclock %s\n\nDatabase: %s\nSchema version: %s\nGo: %s\nPlatform: %s/%s",
+		appVersion, dbPath, schema, runtime.Version(), runtime.GOOS, runtime.GOARCH))
+	info.Wrapping = fyne.TextWrapWord
+	info.SetMinRowsVisible(6)
+
+	d := dialog.NewCustom("About Timeclock", "Close", info, w)
+	d.Resize(fyne.NewSize(480, 260))
+	d.Show()
+}
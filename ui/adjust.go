@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// showAdjustLastIntervalDialog lets the user nudge the start/end of the most
+// recently closed interval. onAdjusted is called after a successful change.
+func showAdjustLastIntervalDialog(w fyne.Window, state *domain.AppState, onAdjusted func()) {
+	iv, err := storage.LastClosedInterval(state.DB)
+	if err == sql.ErrNoRows {
+		notifyError(w, "Nothing to adjust", fmt.Errorf("no interval has been closed yet"))
+		return
+	}
+	if err != nil {
+		notifyError(w, "Adjust error", err)
+		return
+	}
+
+	startEntry := widget.NewEntry()
+	startEntry.SetText(iv.StartUTC.Local().Format(dateTimeLayout))
+	endEntry := widget.NewEntry()
+	endEntry.SetText(iv.EndUTC.Local().Format(dateTimeLayout))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Entry", widget.NewLabel(fmt.Sprintf("%s  %s", iv.Category, iv.Description))),
+		widget.NewFormItem("Start", startEntry),
+		widget.NewFormItem("End", endEntry),
+	}
+	d := dialog.NewForm("Adjust last entry", "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		start, err := parseLocalDateTime(startEntry.Text)
+		if err != nil {
+			notifyError(w, "Invalid start", err)
+			return
+		}
+		end, err := parseLocalDateTime(endEntry.Text)
+		if err != nil {
+			notifyError(w, "Invalid end", err)
+			return
+		}
+		save := func() {
+			if err := state.AdjustLastInterval(start, end); err != nil {
+				notifyError(w, "Adjust error", err)
+				return
+			}
+			onAdjusted()
+		}
+
+		// Moving the entry onto other recorded time would count it twice
+		overlaps, err := storage.FindOverlaps(state.DB, start, end)
+		if err != nil {
+			notifyError(w, "Adjust error", err)
+			return
+		}
+		overlaps = slices.DeleteFunc(overlaps, func(o storage.IntervalDetail) bool { return o.ID == iv.ID })
+		if len(overlaps) == 0 {
+			save()
+			return
+		}
+		dialog.ShowConfirm("Overlapping time",
+			"The adjusted entry overlaps time that is already recorded:\n\n"+formatOverlaps(overlaps)+"\n\nSave anyway?",
+			func(ok bool) {
+				if ok {
+					save()
+				}
+			}, w)
+	}, w)
+	d.Resize(fyne.NewSize(420, d.MinSize().Height))
+	d.Show()
+}
+
+// formatOverlaps lists intervals one per line for an overlap warning.
+func formatOverlaps(intervals []storage.IntervalDetail) string {
+	lines := make([]string, len(intervals))
+	for i, iv := range intervals {
+		end := "running"
+		if !iv.EndUTC.IsZero() {
+			end = iv.EndUTC.Local().Format(dateTimeLayout)
+		}
+		lines[i] = fmt.Sprintf("%s – %s  %s  %s", iv.StartUTC.Local().Format(dateTimeLayout), end, iv.Category, iv.Description)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,22 +1,38 @@
 package ui
 
 import (
+	"bytes"
+	"database/sql"
+	"errors"
 	"fmt"
+	"image/color"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/logging"
 	"github.com/1kaius1/Timeclock/reporting"
 	"github.com/1kaius1/Timeclock/storage"
 )
 
+// defaultAutoResumeWindow is how recently a session must have been paused for
+// "auto_resume_paused" to resume it on launch.
+const defaultAutoResumeWindow = "2h"
+
 // RunApp launches the Fyne GUI.
 func RunApp(state *domain.AppState, dbPath string, scale float32, appVersion string, scaleForced bool) {
 	a := app.NewWithID("com.example.timeclock")
@@ -25,6 +41,9 @@ func RunApp(state *domain.AppState, dbPath string, scale float32, appVersion str
 	// Load settings from database
 	exactDurationsStr := storage.GetSetting(state.DB, "exact_durations", "false")
 	state.RoundToNearestMinute = (exactDurationsStr != "true")
+	// Read by the elapsed ticker, written by the settings checkbox
+	var showDays atomic.Bool
+	showDays.Store(storage.GetSetting(state.DB, "duration_days", "false") == "true")
 
 	savedScaleStr := storage.GetSetting(state.DB, "scale", "1.0")
 	savedScale, _ := strconv.ParseFloat(savedScaleStr, 32)
@@ -36,20 +55,55 @@ func RunApp(state *domain.AppState, dbPath string, scale float32, appVersion str
 	descEntry := widget.NewEntry()
 	descEntry.PlaceHolder = "Description of work..."
 	
-	// If state was restored, populate the description field
+	// Optional ticket/issue id (e.g. a Jira key) for the session
+	issueEntry := widget.NewEntry()
+	issueEntry.PlaceHolder = "Issue (optional, e.g. PROJ-123)"
+
+	// Optional short label grouping the session above category/description
+	labelEntry := widget.NewEntry()
+	labelEntry.PlaceHolder = "Label (optional, e.g. Morning block)"
+
+	// Billable flag of the session; editable while it runs
+	billableCheck := widget.NewCheck("Billable", nil)
+	billableCheck.SetChecked(state.Billable)
+	billableCheck.OnChanged = func(checked bool) {
+		if err := state.SetBillable(checked); err != nil {
+			notifyError(w, "Failed to update billable flag", err)
+		}
+	}
+
+	// If state was restored, populate the description, issue and label fields
 	if state.CurrentState != domain.Stopped {
 		descEntry.SetText(state.Description)
+		issueEntry.SetText(state.IssueID)
+		labelEntry.SetText(state.Label)
+	}
+
+	// Keep a description typed before Start across crashes
+	draft := newDescriptionDraft(state.DB)
+	if state.CurrentState == domain.Stopped {
+		if text := draft.load(); text != "" {
+			descEntry.SetText(text)
+		}
+	}
+	descEntry.OnChanged = func(text string) {
+		if state.Snapshot().State == domain.Stopped {
+			draft.changed(text)
+		}
 	}
 
-	categoryOpts := []string{"Task", "Project", "Meeting", "Training", "Mentoring", "Incident", "Major Incident"}
+	categoryOpts := storage.CategoryOptions(state.DB)
 	categorySelect := widget.NewSelect(categoryOpts, func(string) {})
 	categorySelect.PlaceHolder = "Select category"
 	
 	// If state was restored, select the category
 	if state.CurrentState != domain.Stopped {
-		categorySelect.SetSelected(state.Category)
+		selectCategory(categorySelect, categoryOpts, state.Category)
 	}
 
+	// Large banner naming the work being tracked (optional)
+	workBanner := newWorkBanner()
+
 	// Declare buttons up-front so closures can capture them
 	var startBtn *widget.Button
 	var pauseBtn *widget.Button
@@ -59,56 +113,124 @@ func RunApp(state *domain.AppState, dbPath string, scale float32, appVersion str
 	stateBind := binding.NewString()
 	_ = stateBind.Set("State: Stopped")
 	stateLabel := widget.NewLabelWithData(stateBind)
+	stateDot := canvas.NewCircle(stateColor(domain.Stopped))
 
 	elapsedBind := binding.NewString()
 	_ = elapsedBind.Set("Elapsed: 00m")
 	elapsedLabel := widget.NewLabelWithData(elapsedBind)
+	sinceBreakLabel := widget.NewLabel("")
+	progress := newProgressRing()
+
+	// Daily totals for the trailing week, redrawn after each transition
+	weekSparkline := newSparkline()
+	refreshSparkline := func() {
+		if err := weekSparkline.load(state.DB); err != nil {
+			notifyError(w, "Sparkline error", err)
+		}
+	}
+	refreshSparkline()
 
 	// Recent events list - shows last 5 state changes
 	recentEventsList := widget.NewList(
 		func() int { return 0 }, // will be updated dynamically
-		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
-		},
+		newRecentEventTemplate,
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			// will be updated dynamically
 		},
 	)
 
+	// Session of each listed event, for drilling into it on selection.
+	// refreshAfterTrash is set once the widgets it refreshes exist.
+	var recentSessionIDs []string
+	var refreshAfterTrash func()
+	recentEventsList.OnSelected = func(id widget.ListItemID) {
+		recentEventsList.Unselect(id)
+		if id >= len(recentSessionIDs) {
+			return
+		}
+		sessionID := recentSessionIDs[id]
+		showDetail := func() {
+			showSessionDetailDialog(w, state, sessionID, categoryOpts, refreshAfterTrash)
+		}
+		// The newest row of the live session also offers its controls
+		if snap := state.Snapshot(); id == 0 && snap.State != domain.Stopped && snap.SessionID == sessionID {
+			showLiveSessionActions(w, pauseBtn, stopBtn, showDetail)
+			return
+		}
+		showDetail()
+	}
+
 	// Function to refresh recent events from database
 	refreshRecentEvents := func() {
+		// PAUSE/STOP rows pick up the interval they closed (same session, ending at
+		// the event's timestamp); a STOP after a PAUSE closed nothing and gets NULL.
 		rows, err := state.DB.Query(`
-SELECT timestamp_utc, action, category, description
-FROM events
-ORDER BY id DESC
-LIMIT 5;
-`)
+SELECT e.session_id, e.timestamp_utc, e.action, e.category, COALESCE(e.label, ''), e.description,
+       (SELECT i.duration_seconds
+        FROM intervals i
+        WHERE e.action IN ('PAUSE', 'STOP')
+          AND i.session_id = e.session_id
+          AND i.end_utc = e.timestamp_utc
+        ORDER BY i.id DESC
+        LIMIT 1)
+FROM events e
+WHERE e.deleted_at IS NULL
+ORDER BY e.id DESC
+LIMIT ?;
+`, recentEventsLimit)
 		if err != nil {
+			recentSessionIDs = nil
+			showRecentPlaceholder(recentEventsList, recentLoadFailed)
+			notifyError(w, "Failed to load recent activity", err)
 			return
 		}
 		defer rows.Close()
 
-		var events []string
+		prefs := loadRecentEventsPrefs(state.DB)
+		var events [][]string
+		var sessionIDs []string
+		var scanErr error
 		for rows.Next() {
 			var timestampUTC int64
-			var action, category, description string
-			if err := rows.Scan(&timestampUTC, &action, &category, &description); err != nil {
+			var sessionID, action, category, label, description string
+			var durationSeconds sql.NullInt64
+			if err := rows.Scan(&sessionID, &timestampUTC, &action, &category, &label, &description, &durationSeconds); err != nil {
+				scanErr = err
+				logging.Warnf("skip recent event row: %v", err)
 				continue
 			}
-			t := time.Unix(timestampUTC, 0).Local()
-			timeStr := t.Format("2006-01-02 15:04:05")
-			desc := description
-			if len(desc) > 30 {
-				desc = desc[:27] + "..."
+			duration := ""
+			if durationSeconds.Valid {
+				duration = reporting.FormatDuration(time.Duration(durationSeconds.Int64)*time.Second, state.RoundToNearestMinute)
 			}
-			events = append(events, fmt.Sprintf("%s  %s  %s  %s", timeStr, action, category, desc))
+			// Cells in recentColumns order
+			events = append(events, []string{
+				time.Unix(timestampUTC, 0).Local().Format(prefs.timeLayout),
+				action,
+				category,
+				label,
+				prefs.truncate(description),
+				duration,
+			})
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+		if err := rows.Err(); err != nil {
+			scanErr = err
+			notifyError(w, "Failed to load recent activity", err)
+		}
+		recentSessionIDs = sessionIDs
+
+		// Every row failed: say so rather than showing an empty list
+		if len(events) == 0 && scanErr != nil {
+			showRecentPlaceholder(recentEventsList, recentLoadFailed)
+			return
 		}
 
 		// Update list
 		recentEventsList.Length = func() int { return len(events) }
 		recentEventsList.UpdateItem = func(id widget.ListItemID, obj fyne.CanvasObject) {
 			if id < len(events) {
-				obj.(*widget.Label).SetText(events[id])
+				updateRecentEventItem(obj, events[id], prefs)
 			}
 		}
 		recentEventsList.Refresh()
@@ -135,17 +257,541 @@ LIMIT 5;
 	presenceScroll := container.NewScroll(presenceOutput)
 	presenceScroll.SetMinSize(fyne.NewSize(400, 80))
 
+	breaksOutput := widget.NewLabel("Breaks by pause reason will appear here...")
+	breaksOutput.Wrapping = fyne.TextWrapWord
+
+	// Focus vs fragmented time, split at a configurable interval length
+	focusThresholdEntry := widget.NewEntry()
+	focusThresholdEntry.SetText(storage.GetSetting(state.DB, "focus_threshold", "25m"))
+	focusThresholdEntry.OnChanged = func(text string) {
+		if d, err := domain.ParseDurationInput(text); err == nil && d > 0 {
+			if err := storage.SetSetting(state.DB, "focus_threshold", strings.TrimSpace(text)); err != nil {
+				notifyError(w, "Failed to save setting", err)
+			}
+		}
+	}
+	focusOutput := widget.NewLabel("")
+	focusOutput.Wrapping = fyne.TextWrapWord
+
+	// Regular vs overtime per day, split at a configurable daily threshold
+	overtimeThresholdEntry := widget.NewEntry()
+	overtimeThresholdEntry.SetText(storage.GetSetting(state.DB, "overtime_threshold", "8h"))
+	overtimeThresholdEntry.OnChanged = func(text string) {
+		if d, err := domain.ParseDurationInput(text); err == nil && d > 0 {
+			if err := storage.SetSetting(state.DB, "overtime_threshold", strings.TrimSpace(text)); err != nil {
+				notifyError(w, "Failed to save setting", err)
+			}
+		}
+	}
+	overtimeOutput := widget.NewLabel("")
+	overtimeOutput.TextStyle = fyne.TextStyle{Monospace: true}
+
+	issuesOutput := widget.NewLabel("")
+	issuesOutput.Wrapping = fyne.TextWrapWord
+
+	labelsOutput := widget.NewLabel("")
+	labelsOutput.Wrapping = fyne.TextWrapWord
+
+	// Billed totals: each category's total rounded up to a configurable step
+	billingRoundEntry := widget.NewEntry()
+	billingRoundEntry.SetText(storage.GetSetting(state.DB, "billing_round_to", "15m"))
+	billingRoundEntry.OnChanged = func(text string) {
+		if d, err := domain.ParseDurationInput(text); err == nil && d > 0 {
+			if err := storage.SetSetting(state.DB, "billing_round_to", strings.TrimSpace(text)); err != nil {
+				notifyError(w, "Failed to save setting", err)
+			}
+		}
+	}
+	billedOutput := widget.NewLabel("")
+	billedOutput.TextStyle = fyne.TextStyle{Monospace: true}
+
+	billableOutput := widget.NewLabel("")
+	billableOutput.TextStyle = fyne.TextStyle{Monospace: true}
+	// Payroll export can leave out non-billable sessions
+	payrollBillableOnlyCheck := widget.NewCheck("Billable only", nil)
+
+	// Each category's total with its descriptions nested underneath
+	breakdownOutput := widget.NewLabel("")
+	breakdownOutput.TextStyle = fyne.TextStyle{Monospace: true}
+
+	// Totals per weekday, Monday first
+	weekdayOutput := widget.NewLabel("")
+	weekdayOutput.TextStyle = fyne.TextStyle{Monospace: true}
+
+	sessionExtremesOutput := widget.NewLabel("")
+	sessionExtremesOutput.Wrapping = fyne.TextWrapWord
+
+	// Day × category matrix (rebuilt on each report run)
+	matrix := newDayCategoryMatrix(nil)
+	matrixTable := widget.NewTable(
+		func() (int, int) { return matrix.size() },
+		func() fyne.CanvasObject { return widget.NewLabel("00h 00m 00s") },
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(matrix.cellText(id.Row, id.Col, state.RoundToNearestMinute))
+		},
+	)
+	matrixTable.SetColumnWidth(0, 110)
+	matrixBackground := canvas.NewRectangle(color.Transparent)
+	matrixBackground.SetMinSize(fyne.NewSize(400, 180))
+	matrixArea := container.NewStack(matrixBackground, matrixTable)
+
+	// Optional single-category view: that category's per-day totals instead of
+	// totals per category. The blank choice means all categories.
+	const allCategoriesLabel = "All categories"
+	reportCategorySelect := widget.NewSelect(append([]string{allCategoriesLabel}, categoryOpts...), nil)
+	reportCategorySelect.SetSelected(storage.GetSetting(state.DB, "report_category", allCategoriesLabel))
+	reportCategorySelect.OnChanged = func(choice string) {
+		if err := storage.SetSetting(state.DB, "report_category", choice); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Categories excluded from totals (e.g. non-billable work), persisted between runs
+	excludeCheck := widget.NewCheckGroup(categoryOpts, nil)
+	if saved := storage.DecodeCategoryList(storage.GetSetting(state.DB, "report_exclude_categories", "")); len(saved) > 0 {
+		excludeCheck.SetSelected(saved)
+	}
+	excludeCheck.OnChanged = func(selected []string) {
+		if err := storage.SetSetting(state.DB, "report_exclude_categories", storage.EncodeCategoryList(selected)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Whether excluded categories still count toward presence days
+	presenceIncludesExcludedCheck := widget.NewCheck("Excluded categories still count as presence", nil)
+	presenceIncludesExcludedCheck.SetChecked(storage.GetSetting(state.DB, "presence_include_excluded", "false") == "true")
+	presenceIncludesExcludedCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "presence_include_excluded", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Merge short breaks into the surrounding work (reporting only)
+	mergeGapEntry := widget.NewEntry()
+	mergeGapEntry.SetText(storage.GetSetting(state.DB, "merge_gap_minutes", "0"))
+	mergeGapEntry.OnChanged = func(text string) {
+		// The setting holds whole minutes, so sub-minute values are not saved
+		if d, err := domain.ParseDurationInput(text); err == nil && d%time.Minute == 0 {
+			if err := storage.SetSetting(state.DB, "merge_gap_minutes", strconv.Itoa(int(d/time.Minute))); err != nil {
+				notifyError(w, "Failed to save setting", err)
+			}
+		}
+	}
+	mergeGapHelp := widget.NewLabel("Breaks shorter than this (whole minutes, e.g. 5, 5m, 0:05) between intervals of the same session and category are counted as work, so pause/resume bursts don't fragment totals. Stored data is not changed. 0 disables merging.")
+	mergeGapHelp.Wrapping = fyne.TextWrapWord
+
+	// Weekly goal and the streak of weeks that met it
+	goalStreakLabel := widget.NewLabel("")
+	weeklyGoalEntry := widget.NewEntry()
+	weeklyGoalEntry.PlaceHolder = "e.g. 40h or 37:30"
+	weeklyGoalEntry.SetText(storage.GetSetting(state.DB, "weekly_goal", ""))
+	refreshGoalStreak := func() {
+		goal, err := domain.ParseDurationInput(storage.GetSetting(state.DB, "weekly_goal", ""))
+		if err != nil || goal <= 0 {
+			goalStreakLabel.SetText("")
+			return
+		}
+		current, longest, err := reporting.GoalStreak(state.DB, int64(goal/time.Second), time.Now())
+		if err != nil {
+			notifyError(w, "Goal streak error", err)
+			return
+		}
+		goalStreakLabel.SetText(fmt.Sprintf("Current streak: %s (longest %s)", pluralWeeks(current), pluralWeeks(longest)))
+	}
+	weeklyGoalEntry.OnChanged = func(text string) {
+		text = strings.TrimSpace(text)
+		if _, err := domain.ParseDurationInput(text); err != nil && text != "" {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "weekly_goal", text); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		refreshGoalStreak()
+	}
+
+	// Live countdown to the overall daily goal
+	goalCountdownLabel := widget.NewLabel("")
+	var countdown goalCountdown
+	refreshGoalCountdown := func() {
+		if err := countdown.refresh(state); err != nil {
+			notifyError(w, "Daily goal error", err)
+		}
+		goalCountdownLabel.SetText(countdown.text(state.Snapshot(), time.Now(), state.RoundToNearestMinute))
+	}
+	dailyGoalEntry := widget.NewEntry()
+	dailyGoalEntry.PlaceHolder = "e.g. 8h or 7:30 (empty for none)"
+	dailyGoalEntry.SetText(storage.GetSetting(state.DB, "daily_goal", ""))
+	dailyGoalEntry.OnChanged = func(text string) {
+		text = strings.TrimSpace(text)
+		if _, err := domain.ParseDurationInput(text); err != nil && text != "" {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "daily_goal", text); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		refreshGoalCountdown()
+	}
+
+	// Target length for one interval, shown as the fill of the progress ring.
+	// Only touched on the UI goroutine (entry callback and the ticker's fyne.Do).
+	intervalTargetEntry := widget.NewEntry()
+	intervalTargetEntry.PlaceHolder = "e.g. 25m or 1:30 (empty for none)"
+	intervalTargetEntry.SetText(storage.GetSetting(state.DB, "interval_target", ""))
+	intervalTarget, _ := domain.ParseDurationInput(intervalTargetEntry.Text)
+	intervalTargetEntry.OnChanged = func(text string) {
+		text = strings.TrimSpace(text)
+		d, err := domain.ParseDurationInput(text)
+		if err != nil && text != "" {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "interval_target", text); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		intervalTarget = d
+	}
+
+	// Continuous work after which "Since break" turns red. Same threading as intervalTarget.
+	breakReminderEntry := widget.NewEntry()
+	breakReminderEntry.PlaceHolder = "e.g. 90m (empty for no warning)"
+	breakReminderEntry.SetText(storage.GetSetting(state.DB, "break_reminder", "90m"))
+	breakReminder, _ := domain.ParseDurationInput(breakReminderEntry.Text)
+	breakReminderEntry.OnChanged = func(text string) {
+		text = strings.TrimSpace(text)
+		d, err := domain.ParseDurationInput(text)
+		if err != nil && text != "" {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "break_reminder", text); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		breakReminder = d
+	}
+
+	// Today's total per category against its daily goal
+	categoryGoalsBox := container.NewVBox()
+	refreshCategoryGoals := func() {
+		lines, err := categoryGoalLines(state, categoryOpts)
+		if err != nil {
+			notifyError(w, "Category goals error", err)
+			return
+		}
+		categoryGoalsBox.Objects = lines
+		categoryGoalsBox.Refresh()
+	}
+	categoryGoalsForm := container.NewVBox(newCategoryGoalsForm(w, state, categoryOpts, refreshCategoryGoals))
+
+	// This month's time per category against its monthly budget
+	monthlyBudgetsBox := container.NewVBox()
+	refreshMonthlyBudgets := func() {
+		rows, err := monthlyBudgetRows(state, categoryOpts, time.Now())
+		if err != nil {
+			notifyError(w, "Monthly budgets error", err)
+			return
+		}
+		monthlyBudgetsBox.Objects = rows
+		monthlyBudgetsBox.Refresh()
+	}
+	monthlyBudgetsForm := container.NewVBox(newMonthlyBudgetsForm(w, state, categoryOpts, refreshMonthlyBudgets))
+	payrollCodesForm := container.NewVBox(newPayrollCodesForm(w, state, categoryOpts))
+
 	// --- Settings Tab Widgets ---
 	
 	// Exact durations checkbox
+	// Live example of both formats, since the toggle only changes display
+	roundingPreviewLabel := widget.NewLabel(roundingPreview(exactDurationsStr == "true"))
+	roundingPreviewLabel.Wrapping = fyne.TextWrapWord
 	exactDurationsCheck := widget.NewCheck("Show exact durations (seconds)", func(checked bool) {
-		state.RoundToNearestMinute = !checked
+		state.SetRoundToNearestMinute(!checked)
+		roundingPreviewLabel.SetText(roundingPreview(checked))
 		if err := storage.SetSetting(state.DB, "exact_durations", fmt.Sprintf("%t", checked)); err != nil {
 			notifyError(w, "Failed to save setting", err)
 		}
 	})
 	exactDurationsCheck.SetChecked(exactDurationsStr == "true")
 
+	// Split durations of a day or more into days, e.g. a forgotten session
+	durationDaysCheck := widget.NewCheck("Show days for durations over 24h (2d 3h 12m)", nil)
+	durationDaysCheck.SetChecked(showDays.Load())
+	durationDaysCheck.OnChanged = func(checked bool) {
+		showDays.Store(checked)
+		if err := storage.SetSetting(state.DB, "duration_days", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Ask why work was paused
+	pauseReasonCheck := widget.NewCheck("Ask for a reason when pausing", nil)
+	pauseReasonCheck.SetChecked(storage.GetSetting(state.DB, "prompt_pause_reason", "false") == "true")
+	pauseReasonCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "prompt_pause_reason", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Ask why work stopped before the category's daily goal was reached
+	stopReasonCheck := widget.NewCheck("Prompt reason when stopping under goal", nil)
+	stopReasonCheck.SetChecked(storage.GetSetting(state.DB, "prompt_stop_reason_under_goal", "false") == "true")
+	stopReasonCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "prompt_stop_reason_under_goal", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Intervals shorter than this are dropped as accidental clicks
+	minIntervalEntry := widget.NewEntry()
+	minIntervalEntry.SetText(storage.GetSetting(state.DB, "min_interval_seconds", "0"))
+	minIntervalEntry.OnChanged = func(text string) {
+		n, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil || n < 0 {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "min_interval_seconds", strconv.Itoa(n)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	minIntervalHelp := widget.NewLabel("Pausing or stopping an interval shorter than this discards it entirely, with no pause/stop recorded. 0 disables.")
+	minIntervalHelp.Wrapping = fyne.TextWrapWord
+
+	// Keep Stop disabled until an interval has run this long
+	minStopEntry := widget.NewEntry()
+	minStopEntry.SetText(storage.GetSetting(state.DB, "min_stop_seconds", "0"))
+	minStopEntry.OnChanged = func(text string) {
+		n, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil || n < 0 {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "min_stop_seconds", strconv.Itoa(n)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// A restored interval running longer than this is probably a crash
+	staleRestoreEntry := widget.NewEntry()
+	staleRestoreEntry.SetText(storage.GetSetting(state.DB, "stale_restore_hours", strconv.Itoa(defaultStaleRestoreHours)))
+	staleRestoreEntry.OnChanged = func(text string) {
+		n, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil || n < 0 {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "stale_restore_hours", strconv.Itoa(n)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	staleRestoreHelp := widget.NewLabel("When a restored interval has been running longer than this, the restore dialog suggests stopping it at its last recorded activity and asks before keeping it running. 0 disables.")
+	staleRestoreHelp.Wrapping = fyne.TextWrapWord
+
+	// Nudge towards describing work before starting it
+	warnEmptyDescCheck := widget.NewCheck("Warn when starting without a description", nil)
+	warnEmptyDescCheck.SetChecked(storage.GetSetting(state.DB, "warn_empty_description", "false") == "true")
+	warnEmptyDescCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "warn_empty_description", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Large banner with the current category/description on the Track tab
+	workBannerCheck := widget.NewCheck("Show the current work in a large banner", nil)
+	workBannerCheck.SetChecked(storage.GetSetting(state.DB, "show_work_banner", "false") == "true")
+	workBannerCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "show_work_banner", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		updateWorkBanner(workBanner, state)
+	}
+
+	// Start each session from blank fields instead of the last values
+	clearOnStopCheck := widget.NewCheck("Clear description and category on stop", nil)
+	clearOnStopCheck.SetChecked(storage.GetSetting(state.DB, "clear_fields_on_stop", "false") == "true")
+	clearOnStopCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "clear_fields_on_stop", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Save the description typed before Start so a crash doesn't lose it
+	draftCheck := widget.NewCheck("Autosave the description before starting", nil)
+	draftCheck.SetChecked(draft.enabled())
+	draftCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "autosave_description_draft", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		if !checked {
+			draft.clear()
+		}
+	}
+
+	// Zone that new intervals are bucketed into days in
+	reportTZEntry := widget.NewSelectEntry([]string{"Local", "UTC"})
+	reportTZEntry.SetText(storage.GetSetting(state.DB, "report_timezone", "Local"))
+	reportTZStatus := widget.NewLabel("")
+	reportTZEntry.OnChanged = func(text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		if text != "Local" {
+			if _, err := time.LoadLocation(text); err != nil {
+				reportTZStatus.SetText("Unknown timezone; not saved")
+				return
+			}
+		}
+		if err := storage.SetSetting(state.DB, "report_timezone", text); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		reportTZStatus.SetText("")
+	}
+	reportTZHelp := widget.NewLabel("Which calendar day work counts toward: Local, UTC, or a zone name like Europe/Berlin. Only affects intervals recorded from now on; existing days keep their local dates.")
+	reportTZHelp.Wrapping = fyne.TextWrapWord
+
+	// Time of day a new day starts, for work that runs past midnight
+	dayBoundaryEntry := widget.NewEntry()
+	dayBoundaryEntry.PlaceHolder = "00:00"
+	dayBoundaryEntry.SetText(storage.GetSetting(state.DB, "day_boundary", "00:00"))
+	dayBoundaryStatus := widget.NewLabel("")
+	dayBoundaryEntry.OnChanged = func(text string) {
+		if _, err := storage.ParseDayBoundary(text); err != nil {
+			dayBoundaryStatus.SetText("Use HH:MM; not saved")
+			return
+		}
+		if err := storage.SetSetting(state.DB, "day_boundary", strings.TrimSpace(text)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		dayBoundaryStatus.SetText("")
+	}
+	dayBoundaryHelp := widget.NewLabel("Work before this time counts toward the previous day, e.g. 04:00 for late nights. Use Rebuild daily data to apply it to past work.")
+	dayBoundaryHelp.Wrapping = fyne.TextWrapWord
+
+	// JSON status file for external status bars, rewritten from the ticker
+	statusFileCheck := widget.NewCheck("Write a JSON status file", nil)
+	statusFileCheck.SetChecked(storage.GetSetting(state.DB, "status_file_enabled", "false") == "true")
+	statusFileCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "status_file_enabled", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	statusFileEntry := widget.NewEntry()
+	statusFileEntry.PlaceHolder = domain.DefaultStatusFilePath(dbPath)
+	statusFileEntry.SetText(storage.GetSetting(state.DB, "status_file_path", ""))
+	statusFileEntry.OnChanged = func(text string) {
+		if err := storage.SetSetting(state.DB, "status_file_path", strings.TrimSpace(text)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Webhook notified on every Start/Pause/Resume/Stop
+	webhookEntry := widget.NewEntry()
+	webhookEntry.PlaceHolder = "https://example.com/hook (empty to disable)"
+	webhookEntry.SetText(storage.GetSetting(state.DB, "webhook_url", ""))
+	webhookEntry.OnChanged = func(text string) {
+		if err := storage.SetSetting(state.DB, "webhook_url", strings.TrimSpace(text)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	testWebhookBtn := widget.NewButton("Test webhook", func() {
+		url := strings.TrimSpace(webhookEntry.Text)
+		if url == "" {
+			notifyError(w, "Webhook", fmt.Errorf("no webhook URL set"))
+			return
+		}
+		ev := domain.TransitionEvent{Action: "TEST", Description: "Timeclock webhook test", Timestamp: time.Now().UTC()}
+		go func() {
+			err := domain.PostWebhook(url, ev)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("webhook failed: %w", err), w)
+					return
+				}
+				dialog.ShowInformation("Webhook", "Test payload delivered.", w)
+			})
+		}()
+	})
+
+	// Layout of the recent activity list
+	recentEventsSettings := newRecentEventsSettings(w, state.DB, refreshRecentEvents)
+
+	// What closing the window does to work in progress
+	closeActionSelect := widget.NewSelect(closeActions, nil)
+	closeActionSelect.SetSelected(storage.GetSetting(state.DB, "close_action", closeActionAsk))
+	closeActionSelect.OnChanged = func(choice string) {
+		if err := storage.SetSetting(state.DB, "close_action", choice); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Verbosity of the log; the -log-level flag takes precedence at startup
+	logLevelSelect := widget.NewSelect([]string{"debug", "info", "warn", "error"}, nil)
+	logLevelSelect.SetSelected(logging.CurrentLevel().String())
+	logLevelSelect.OnChanged = func(choice string) {
+		lvl, err := logging.ParseLevel(choice)
+		if err != nil {
+			return
+		}
+		logging.SetLevel(lvl)
+		if err := storage.SetSetting(state.DB, "log_level", choice); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Pause automatically at a fixed local time, e.g. a hard stop at 17:00
+	autoPauseEntry := widget.NewEntry()
+	autoPauseEntry.PlaceHolder = "HH:MM (empty to disable)"
+	autoPauseEntry.SetText(storage.GetSetting(state.DB, "auto_pause_time", ""))
+	autoPauseStatus := widget.NewLabel("")
+	autoPauseNext := newAutoPauseIndicator(w, state.DB)
+	autoPauseEntry.OnChanged = func(text string) {
+		text = strings.TrimSpace(text)
+		if text != "" {
+			t, err := time.Parse("15:04", text)
+			if err != nil {
+				autoPauseStatus.SetText("Use HH:MM; not saved")
+				return
+			}
+			text = t.Format("15:04") // compared against the clock as HH:MM
+		}
+		if err := storage.SetSetting(state.DB, "auto_pause_time", text); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		autoPauseStatus.SetText("")
+		autoPauseNext.refresh(time.Now())
+	}
+
+	// Always-on-top while tracking, so a running timer isn't forgotten
+	alwaysOnTopCheck := widget.NewCheck("Keep window on top while work is in progress", nil)
+	alwaysOnTopCheck.SetChecked(storage.GetSetting(state.DB, "always_on_top", "false") == "true")
+	applyAlwaysOnTop := func() {
+		setAlwaysOnTop(w, alwaysOnTopCheck.Checked && state.Snapshot().State == domain.InProgress)
+	}
+	alwaysOnTopCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "always_on_top", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		applyAlwaysOnTop()
+	}
+
+	// Elapsed minutes on the dock icon (macOS only); read on the UI thread
+	var badge dockBadge
+	dockBadgeCheck := widget.NewCheck("Show elapsed minutes on the dock icon", nil)
+	dockBadgeCheck.SetChecked(storage.GetSetting(state.DB, "dock_badge", "false") == "true")
+	dockBadgeCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "dock_badge", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		if !checked {
+			badge.update("")
+		}
+	}
+	if !dockBadgeSupported {
+		dockBadgeCheck.Disable()
+		dockBadgeCheck.Text += " (not supported on this platform)"
+	}
+
 	// Scale slider and entry
 	scaleValueLabel := widget.NewLabel(fmt.Sprintf("%.2f", savedScale))
 	scaleEntry := widget.NewEntry()
@@ -161,198 +807,1187 @@ LIMIT 5;
 		scaleEntry.SetText(fmt.Sprintf("%.2f", value))
 	}
 
-	// Scale entry callback
-	scaleEntry.OnChanged = func(text string) {
-		if val, err := strconv.ParseFloat(text, 64); err == nil {
-			if val >= 0.5 && val <= 3.0 {
-				scaleSlider.SetValue(val)
-				scaleValueLabel.SetText(fmt.Sprintf("%.2f", val))
+	// Save scale button with message label
+	saveScaleMessage := widget.NewLabel("")
+	saveScaleBtn := widget.NewButton("Save Scale", func() {
+		val, err := strconv.ParseFloat(strings.TrimSpace(scaleEntry.Text), 64)
+		if err != nil || val < 0.5 || val > 3.0 {
+			notifyError(w, "Invalid scale", fmt.Errorf("scale must be between 0.5 and 3.0"))
+			return
+		}
+		if err := storage.SetSetting(state.DB, "scale", fmt.Sprintf("%.2f", val)); err != nil {
+			notifyError(w, "Failed to save scale", err)
+			return
+		}
+		saveScaleMessage.SetText("Scale saved. Restart the application for changes to take effect.")
+		time.AfterFunc(5*time.Second, func() {
+			saveScaleMessage.SetText("")
+		})
+	})
+
+	// Scale entry callback: validate as the user types and only allow saving valid values
+	scaleEntry.Validator = validateScale
+	scaleEntry.OnChanged = func(text string) {
+		if err := validateScale(text); err != nil {
+			saveScaleBtn.Disable()
+			saveScaleMessage.SetText(err.Error())
+			return
+		}
+		saveScaleBtn.Enable()
+		saveScaleMessage.SetText("")
+		val, _ := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		scaleSlider.SetValue(val)
+		scaleValueLabel.SetText(fmt.Sprintf("%.2f", val))
+	}
+
+	// Scale status information
+	var scaleStatusText string
+	if scaleForced {
+		scaleStatusText = fmt.Sprintf("Current scale: %.2f (forced by -scale flag)\nSaved scale: %.2f (will be used when flag is not provided)", scale, savedScale)
+	} else {
+		scaleStatusText = fmt.Sprintf("Current scale: %.2f (from database)\nNo -scale flag provided", scale)
+	}
+	scaleStatus := widget.NewLabel(scaleStatusText)
+	scaleStatus.Wrapping = fyne.TextWrapWord
+
+	// Launch behavior
+	autoStartCategorySelect := widget.NewSelect(categoryOpts, func(selected string) {
+		if err := storage.SetSetting(state.DB, "auto_start_category", selected); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	})
+	autoStartCategorySelect.PlaceHolder = "Default category"
+	if saved := storage.GetSetting(state.DB, "auto_start_category", ""); saved != "" {
+		autoStartCategorySelect.Selected = saved
+	}
+	autoStartCheck := widget.NewCheck("Auto-start tracking on launch", nil)
+	autoStartCheck.SetChecked(storage.GetSetting(state.DB, "auto_start", "false") == "true")
+	autoStartCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "auto_start", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	// Treat quitting while paused as a break: resume on the next launch
+	autoResumeCheck := widget.NewCheck("Auto-resume a paused session on launch", nil)
+	autoResumeCheck.SetChecked(storage.GetSetting(state.DB, "auto_resume_paused", "false") == "true")
+	autoResumeCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "auto_resume_paused", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	autoResumeWindowEntry := widget.NewEntry()
+	autoResumeWindowEntry.PlaceHolder = "e.g. 2h"
+	autoResumeWindowEntry.SetText(storage.GetSetting(state.DB, "auto_resume_window", defaultAutoResumeWindow))
+	autoResumeWindowEntry.OnChanged = func(text string) {
+		if d, err := domain.ParseDurationInput(text); err != nil || d <= 0 {
+			return
+		}
+		if err := storage.SetSetting(state.DB, "auto_resume_window", strings.TrimSpace(text)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	startMinimizedCheck := widget.NewCheck("Start minimized to the system tray", nil)
+	startMinimizedCheck.SetChecked(storage.GetSetting(state.DB, "start_minimized", "false") == "true")
+	startMinimizedCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "start_minimized", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Merge sessions recorded on another machine
+	mergeSkipOverlapsCheck := widget.NewCheck("Skip sessions that overlap time already recorded here", nil)
+	mergeSkipOverlapsCheck.SetChecked(storage.GetSetting(state.DB, "merge_skip_overlaps", "false") == "true")
+	mergeSkipOverlapsCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "merge_skip_overlaps", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	mergeDBBtn := widget.NewButton("Merge from file...", func() {
+		if state.Snapshot().State != domain.Stopped {
+			notifyError(w, "Merge unavailable", fmt.Errorf("stop the current session before merging"))
+			return
+		}
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				notifyError(w, "Merge error", err)
+				return
+			}
+			if reader == nil {
+				return // cancelled
+			}
+			srcPath := reader.URI().Path()
+			reader.Close()
+
+			dialog.ShowConfirm("Merge database",
+				fmt.Sprintf("Copy completed sessions from\n%s\ninto this database? Sessions that already exist here are skipped.", srcPath),
+				func(ok bool) {
+					if !ok {
+						return
+					}
+					merged, skipped, overlapping, err := storage.MergeDatabase(state.DB, srcPath, mergeSkipOverlapsCheck.Checked)
+					if err != nil {
+						notifyError(w, "Merge error", err)
+						return
+					}
+					refreshRecentEvents()
+					msg := fmt.Sprintf("Sessions merged: %d\nSessions skipped: %d", merged, skipped)
+					if overlapping > 0 && mergeSkipOverlapsCheck.Checked {
+						msg += fmt.Sprintf("\n%d of the skipped sessions overlap time already recorded here.", overlapping)
+					} else if overlapping > 0 {
+						msg += fmt.Sprintf("\nWarning: %d merged session(s) overlap time already recorded here and may be counted twice.", overlapping)
+					}
+					dialog.ShowInformation("Merge complete", msg, w)
+				}, w)
+		}, w)
+	})
+
+	// Bring in history from other time trackers
+	importFormatSelect := widget.NewSelect([]string{"Toggl CSV", "Clockify CSV"}, nil)
+	importFormatSelect.SetSelected("Toggl CSV")
+	importSkipOverlapsCheck := widget.NewCheck("Skip imported entries that overlap time already recorded here", nil)
+	importSkipOverlapsCheck.SetChecked(storage.GetSetting(state.DB, "import_skip_overlaps", "true") == "true")
+	importSkipOverlapsCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "import_skip_overlaps", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	importEntriesBtn := widget.NewButton("Import time entries...", func() {
+		if state.Snapshot().State != domain.Stopped {
+			notifyError(w, "Import unavailable", fmt.Errorf("stop the current session before importing"))
+			return
+		}
+		var importCSV csvImporter = storage.ImportTogglCSV
+		if importFormatSelect.Selected == "Clockify CSV" {
+			importCSV = storage.ImportClockifyCSV
+		}
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return // cancelled
+			}
+			defer reader.Close()
+			importTimeEntries(w, state.DB, reader, importCSV, state.AppVersion, importSkipOverlapsCheck.Checked, refreshRecentEvents)
+		}, w)
+	})
+
+	// Carry preferences between machines
+	exportSettingsBtn := widget.NewButton("Export settings...", func() {
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				notifyError(w, "Export error", err)
+				return
+			}
+			if writer == nil {
+				return // cancelled
+			}
+			defer writer.Close()
+			if err := storage.ExportSettings(state.DB, writer); err != nil {
+				notifyError(w, "Export error", err)
+			}
+		}, w)
+	})
+	importSettingsBtn := widget.NewButton("Import settings...", func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				notifyError(w, "Import error", err)
+				return
+			}
+			if reader == nil {
+				return // cancelled
+			}
+			defer reader.Close()
+			if err := storage.ImportSettings(state.DB, reader, domain.ParseDurationInput); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			dialog.ShowInformation("Settings imported", "Restart Timeclock to apply the imported settings.", w)
+		}, w)
+	})
+
+	// Maintenance: compact the database file
+	optimizeDBBtn := widget.NewButton("Optimize Database", func() {
+		if state.Snapshot().State == domain.InProgress {
+			notifyError(w, "Optimize unavailable", fmt.Errorf("pause or stop work before optimizing"))
+			return
+		}
+		before, err := storage.DatabaseSize(dbPath)
+		if err != nil {
+			notifyError(w, "Optimize error", err)
+			return
+		}
+		if err := storage.Vacuum(state.DB); err != nil {
+			notifyError(w, "Optimize error", err)
+			return
+		}
+		after, err := storage.DatabaseSize(dbPath)
+		if err != nil {
+			notifyError(w, "Optimize error", err)
+			return
+		}
+		dialog.ShowInformation("Optimize complete",
+			fmt.Sprintf("Before: %s\nAfter: %s\nReclaimed: %s", formatBytes(before), formatBytes(after), formatBytes(before-after)), w)
+	})
+
+	// Maintenance: rebuild the per-day data from the intervals table
+	rebuildDaysBtn := widget.NewButton("Rebuild daily data...", func() {
+		dialog.ShowConfirm("Rebuild daily data",
+			"Recompute the per-day totals used by reports from the recorded intervals? Use this if reports look out of sync with your entries.",
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				if err := storage.RebuildIntervalDays(state.DB); err != nil {
+					notifyError(w, "Rebuild error", err)
+					return
+				}
+				refreshCategoryGoals()
+				refreshMonthlyBudgets()
+				refreshGoalStreak()
+				dialog.ShowInformation("Rebuild complete", "Daily data has been rebuilt.", w)
+			}, w)
+	})
+
+	// Bulk re-categorization of past work
+	recatOldSelect := widget.NewSelect(categoryOpts, nil)
+	recatOldSelect.PlaceHolder = "From category"
+	recatNewSelect := widget.NewSelect(categoryOpts, nil)
+	recatNewSelect.PlaceHolder = "To category"
+	recatFromEntry := widget.NewEntry()
+	recatFromEntry.PlaceHolder = "From (YYYY-MM-DD)"
+	recatToEntry := widget.NewEntry()
+	recatToEntry.PlaceHolder = "To (YYYY-MM-DD)"
+	recatBtn := widget.NewButton("Re-categorize...", func() {
+		if state.Snapshot().State != domain.Stopped {
+			notifyError(w, "Re-categorize unavailable", fmt.Errorf("stop the current session before re-categorizing"))
+			return
+		}
+		from := strings.TrimSpace(recatFromEntry.Text)
+		to := strings.TrimSpace(recatToEntry.Text)
+		if !isYYYYMMDD(from) || !isYYYYMMDD(to) {
+			notifyError(w, "Invalid date", fmt.Errorf("dates must be YYYY-MM-DD"))
+			return
+		}
+		oldCat, newCat := recatOldSelect.Selected, recatNewSelect.Selected
+		if oldCat == "" || newCat == "" || oldCat == newCat {
+			notifyError(w, "Invalid categories", fmt.Errorf("choose two different categories"))
+			return
+		}
+		n, err := storage.CountRecategorize(state.DB, from, to, oldCat)
+		if err != nil {
+			notifyError(w, "Re-categorize error", err)
+			return
+		}
+		if n == 0 {
+			dialog.ShowInformation("Nothing to change", fmt.Sprintf("No %q entries between %s and %s.", oldCat, from, to), w)
+			return
+		}
+		dialog.ShowConfirm("Re-categorize",
+			fmt.Sprintf("Change %d rows from %q to %q between %s and %s?", n, oldCat, newCat, from, to),
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				affected, err := storage.Recategorize(state.DB, from, to, oldCat, newCat)
+				if err != nil {
+					notifyError(w, "Re-categorize error", err)
+					return
+				}
+				refreshRecentEvents()
+				dialog.ShowInformation("Re-categorize complete", fmt.Sprintf("Rows changed: %d", affected), w)
+			}, w)
+	})
+
+	// Rename a category across all history; renaming onto a used name merges them
+	var refreshCategoryOptions func(oldName, newName string)
+	renameOldEntry := widget.NewSelectEntry(categoryOpts)
+	renameOldEntry.PlaceHolder = "Current name"
+	renameNewEntry := widget.NewSelectEntry(categoryOpts)
+	renameNewEntry.PlaceHolder = "New name"
+	renameCategoryBtn := widget.NewButton("Rename...", func() {
+		if state.Snapshot().State != domain.Stopped {
+			dialog.ShowError(fmt.Errorf("stop the current session before renaming a category"), w)
+			return
+		}
+		oldCat, newCat := strings.TrimSpace(renameOldEntry.Text), strings.TrimSpace(renameNewEntry.Text)
+		if oldCat == "" || newCat == "" || oldCat == newCat {
+			dialog.ShowError(fmt.Errorf("enter two different category names"), w)
+			return
+		}
+		n, err := storage.CountCategoryRows(state.DB, oldCat)
+		if err != nil {
+			notifyError(w, "Rename error", err)
+			return
+		}
+		// An unused category can still be renamed while it is offered
+		if n == 0 && !slices.Contains(categoryOpts, oldCat) {
+			dialog.ShowInformation("Nothing to change", fmt.Sprintf("No entries use %q.", oldCat), w)
+			return
+		}
+		msg := fmt.Sprintf("Rename %q to %q in all %d rows of history, and move its goal, budget and payroll code?", oldCat, newCat, n)
+		if used, err := storage.CountCategoryRows(state.DB, newCat); err == nil && used > 0 {
+			msg += fmt.Sprintf("\n%q is already in use; the two categories will be merged.", newCat)
+		}
+		dialog.ShowConfirm("Rename category", msg, func(ok bool) {
+			if !ok {
+				return
+			}
+			affected, err := storage.RenameCategory(state.DB, oldCat, newCat)
+			if err != nil {
+				notifyError(w, "Rename error", err)
+				return
+			}
+			refreshCategoryOptions(oldCat, newCat)
+			refreshRecentEvents()
+			refreshCategoryGoals()
+			refreshMonthlyBudgets()
+			dialog.ShowInformation("Rename complete", fmt.Sprintf("Rows changed: %d", affected), w)
+		}, w)
+	})
+
+	// Everything ever tracked, for a sense of history
+	lifetimeLabel := widget.NewLabel("")
+	usageView, refreshUsage := newUsagePatternsView(w, state.DB)
+	refreshLifetime := func() {
+		refreshUsage()
+		total, err := reporting.LifetimeTotal(state.DB)
+		if err != nil {
+			notifyError(w, "Lifetime total error", err)
+			return
+		}
+		first, ok, err := reporting.FirstEventTime(state.DB)
+		if err != nil {
+			notifyError(w, "Lifetime total error", err)
+			return
+		}
+		if !ok {
+			lifetimeLabel.SetText("Nothing tracked yet.")
+			return
+		}
+		lifetimeLabel.SetText(fmt.Sprintf("Total tracked: %s since %s",
+			reporting.FormatDuration(time.Duration(total)*time.Second, true), first.Local().Format("2006-01-02")))
+	}
+
+	// Database path (read-only)
+	dbPathLabel := widget.NewLabel(fmt.Sprintf("Database: %s", dbPath))
+	dbPathLabel.Wrapping = fyne.TextWrapWord
+
+	// --- Wire up handlers AFTER widgets exist ---
+
+	// One-click start buttons for chosen categories, usable only while stopped
+	quickStartBox := container.NewHBox()
+	refreshQuickStart := func() {
+		stopped := state.Snapshot().State == domain.Stopped
+		quickStartBox.Objects = nil
+		for _, cat := range storage.DecodeCategoryList(storage.GetSetting(state.DB, "quick_start_categories", "")) {
+			if cat == "" {
+				continue
+			}
+			cat := cat
+			btn := widget.NewButton(cat, func() {
+				selectCategory(categorySelect, categoryOpts, cat)
+				startBtn.OnTapped()
+			})
+			if !stopped {
+				btn.Disable()
+			}
+			quickStartBox.Add(btn)
+		}
+		quickStartBox.Refresh()
+	}
+	quickStartCheck := widget.NewCheckGroup(categoryOpts, nil)
+	quickStartCheck.Horizontal = true
+	if saved := storage.DecodeCategoryList(storage.GetSetting(state.DB, "quick_start_categories", "")); len(saved) > 0 {
+		quickStartCheck.SetSelected(saved)
+	}
+	quickStartCheck.OnChanged = func(selected []string) {
+		if err := storage.SetSetting(state.DB, "quick_start_categories", storage.EncodeCategoryList(selected)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		refreshQuickStart()
+	}
+
+	// refreshCategoryOptions reloads the category options after oldName was
+	// renamed to newName and updates every widget offering them.
+	refreshCategoryOptions = func(oldName, newName string) {
+		categoryOpts = storage.CategoryOptions(state.DB)
+		current := selectedCategory(categorySelect)
+		if current == oldName {
+			current = newName
+		}
+		selectCategory(categorySelect, categoryOpts, current)
+		reportCategorySelect.Options = append([]string{allCategoriesLabel}, categoryOpts...)
+		reportCategorySelect.SetSelected(storage.GetSetting(state.DB, "report_category", allCategoriesLabel))
+		excludeCheck.Options = categoryOpts
+		excludeCheck.SetSelected(storage.DecodeCategoryList(storage.GetSetting(state.DB, "report_exclude_categories", "")))
+		autoStartCategorySelect.Options = categoryOpts
+		autoStartCategorySelect.Selected = storage.GetSetting(state.DB, "auto_start_category", "")
+		autoStartCategorySelect.Refresh()
+		for _, sel := range []*widget.Select{recatOldSelect, recatNewSelect} {
+			sel.Options = categoryOpts
+			if sel.Selected == oldName {
+				sel.ClearSelected()
+			}
+			sel.Refresh()
+		}
+		renameOldEntry.SetOptions(categoryOpts)
+		renameOldEntry.SetText("")
+		renameNewEntry.SetOptions(categoryOpts)
+		renameNewEntry.SetText("")
+		quickStartCheck.Options = categoryOpts
+		quickStartCheck.SetSelected(storage.DecodeCategoryList(storage.GetSetting(state.DB, "quick_start_categories", "")))
+		categoryGoalsForm.Objects = []fyne.CanvasObject{newCategoryGoalsForm(w, state, categoryOpts, refreshCategoryGoals)}
+		categoryGoalsForm.Refresh()
+		monthlyBudgetsForm.Objects = []fyne.CanvasObject{newMonthlyBudgetsForm(w, state, categoryOpts, refreshMonthlyBudgets)}
+		monthlyBudgetsForm.Refresh()
+		payrollCodesForm.Objects = []fyne.CanvasObject{newPayrollCodesForm(w, state, categoryOpts)}
+		payrollCodesForm.Refresh()
+	}
+
+	// refreshAfterTransition brings the widgets in line with the state after a
+	// Start/Pause/Resume/Stop, whichever code path triggered it.
+	refreshAfterTransition := func() {
+		updateUIForState(state, startBtn, pauseBtn, stopBtn, descEntry, issueEntry, labelEntry, categorySelect, stateDot, workBanner)
+		// A running or paused session keeps its stored category; show it even
+		// if the widget was cleared or the category was removed from the list
+		if state.CurrentState != domain.Stopped {
+			selectCategory(categorySelect, categoryOpts, state.Category)
+		}
+		refreshQuickStart()
+		applyAlwaysOnTop()
+		refreshRecentEvents()
+		refreshGoalStreak()
+		refreshCategoryGoals()
+		refreshMonthlyBudgets()
+		refreshGoalCountdown()
+		refreshSparkline()
+		refreshLifetime()
+		// Optional immediate state label update (not required; ticker will update in <1s)
+		_ = stateBind.Set(stateText(state.Snapshot().State))
+	}
+
+	// Trash: sessions moved out of reports, restorable from Settings
+	var refreshTrash func()
+	refreshAfterTrash = func() {
+		refreshRecentEvents()
+		refreshGoalStreak()
+		refreshCategoryGoals()
+		refreshMonthlyBudgets()
+		refreshGoalCountdown()
+		refreshSparkline()
+		refreshLifetime()
+		refreshTrash()
+	}
+	trashView, refreshTrash := newTrashView(w, state, refreshAfterTrash)
+
+	startWork := func() {
+		if err := state.StartWork(strings.TrimSpace(descEntry.Text), selectedCategory(categorySelect), strings.TrimSpace(issueEntry.Text), strings.TrimSpace(labelEntry.Text)); err != nil {
+			notifyError(w, "Start/Resume error", err)
+			return
+		}
+		draft.clear()
+		refreshAfterTransition()
+	}
+	startBtn = widget.NewButton("Start Work", func() {
+		// Only a new session takes a description; resuming keeps the old one
+		if warnEmptyDescCheck.Checked && state.Snapshot().State == domain.Stopped && strings.TrimSpace(descEntry.Text) == "" {
+			dialog.ShowConfirm("No description",
+				"You are starting work without a description. Start anyway?",
+				func(ok bool) {
+					if ok {
+						startWork()
+					}
+				}, w)
+			return
+		}
+		startWork()
+	})
+
+	pauseBtn = widget.NewButton("Pause Work", func() {
+		if err := state.PauseWork(); errors.Is(err, domain.ErrIntervalDiscarded) {
+			// Nothing was recorded, so there is no break to give a reason for
+			refreshAfterTransition()
+			return
+		} else if err != nil {
+			notifyError(w, "Pause error", err)
+			return
+		}
+		refreshAfterTransition()
+		// The timer is already paused; the reason is attached afterwards
+		if pauseReasonCheck.Checked {
+			showPauseReasonDialog(w, state, refreshRecentEvents)
+		}
+	})
+
+	stopBtn = widget.NewButton("Stop Work", func() {
+		// Stopping clears the session, but the reason prompt still needs its id
+		sessionID := state.Snapshot().SessionID
+		err := state.StopWork()
+		discarded := errors.Is(err, domain.ErrIntervalDiscarded)
+		if err != nil && !discarded {
+			notifyError(w, "Stop error", err)
+			return
+		}
+		refreshAfterTransition()
+		// A discarded interval leaves no STOP event to give a reason on
+		if stopReasonCheck.Checked && !discarded {
+			if under, short, err := underDailyGoal(state); err != nil {
+				notifyError(w, "Goal check error", err)
+			} else if under {
+				showStopReasonDialog(w, state, sessionID, short, refreshRecentEvents)
+			}
+		}
+		if clearOnStopCheck.Checked {
+			descEntry.SetText("")
+			issueEntry.SetText("")
+			labelEntry.SetText("")
+			categorySelect.ClearSelected()
+		}
+	})
+
+	// Correct the times of the entry that was just closed
+	adjustBtn := widget.NewButton("Adjust Last Entry", func() {
+		showAdjustLastIntervalDialog(w, state, refreshRecentEvents)
+	})
+
+	// Record a known-length block (e.g. a standup) without running the timer
+	logBlockBtn := widget.NewButton("Log Fixed Block...", func() {
+		showLogBlockDialog(w, state, categoryOpts, refreshAfterTransition)
+	})
+
+	// Reopen a stopped session that turned out to be the same piece of work
+	continueBtn := widget.NewButton("Continue Session...", func() {
+		showContinueSessionDialog(w, state, func() {
+			refreshAfterTransition()
+			descEntry.SetText(state.Description)
+			issueEntry.SetText(state.IssueID)
+			labelEntry.SetText(state.Label)
+			billableCheck.SetChecked(state.Billable)
+		})
+	})
+
+	// Closed when the window closes so the ticker goroutines stop updating the UI
+	tickersDone := make(chan struct{})
+	stopTickers := sync.OnceFunc(func() { close(tickersDone) })
+
+	// Ticker to update elapsed while InProgress (binding handles UI thread safely)
+	go func() {
+		t := time.NewTicker(1 * time.Second)
+		defer t.Stop()
+		lastCheckpoint := time.Now()
+		var lastStatusWrite time.Time
+		var lastStatusState domain.State
+		var lastStatusErr string
+		var lastMinute, autoPausedDate, exportCheckDate string
+		for {
+			select {
+			case <-tickersDone:
+				return
+			case <-t.C:
+			}
+			// Weekly export: checked on the first tick after launch, then once a day
+			if today := time.Now().Format("2006-01-02"); today != exportCheckDate {
+				exportCheckDate = today
+				if path, err := runScheduledExport(state.DB, time.Now()); err != nil {
+					notifyError(w, "Scheduled export error", err)
+				} else if path != "" {
+					fyne.Do(func() {
+						a.SendNotification(fyne.NewNotification("Timeclock", "Last week's report was exported to "+path+"."))
+					})
+				}
+			}
+
+			// Scheduled auto-pause: checked once per minute, firing at most once a
+			// day unless that day was snoozed
+			if now := time.Now(); now.Format("15:04") != lastMinute {
+				lastMinute = now.Format("15:04")
+				today := now.Format("2006-01-02")
+				fyne.Do(func() { autoPauseNext.refresh(now) })
+				if state.Snapshot().State == domain.InProgress && autoPausedDate != today && !autoPauseSnoozed(state.DB, today) &&
+					storage.GetSetting(state.DB, "auto_pause_time", "") == lastMinute {
+					autoPausedDate = today
+					at := lastMinute
+					// Pause on the UI goroutine like the buttons do: refreshAfterTransition
+					// reads the state's fields directly, so the transition must not race it
+					fyne.Do(func() {
+						if state.Snapshot().State != domain.InProgress {
+							return // paused or stopped by hand meanwhile
+						}
+						if err := state.PauseWork(); err != nil && !errors.Is(err, domain.ErrIntervalDiscarded) {
+							notifyError(w, "Auto-pause error", err)
+							return
+						}
+						refreshAfterTransition()
+						a.SendNotification(fyne.NewNotification("Timeclock", "Work was paused automatically at "+at+"."))
+					})
+				}
+			}
+
+			// Take one consistent snapshot per tick instead of reading fields directly
+			snap := state.Snapshot()
+			el := snap.Elapsed
+
+			// Refresh the status file every few seconds, and right away on a transition
+			if time.Since(lastStatusWrite) >= domain.StatusFileInterval || snap.State != lastStatusState {
+				lastStatusWrite, lastStatusState = time.Now(), snap.State
+				if storage.GetSetting(state.DB, "status_file_enabled", "false") == "true" {
+					path := storage.GetSetting(state.DB, "status_file_path", "")
+					if path == "" {
+						path = domain.DefaultStatusFilePath(dbPath)
+					}
+					// Report a failing path once rather than on every write
+					if err := domain.WriteStatusFile(path, snap); err != nil && err.Error() != lastStatusErr {
+						lastStatusErr = err.Error()
+						notifyError(w, "Status file error", err)
+					} else if err == nil {
+						lastStatusErr = ""
+					}
+				}
+			}
+
+			// Periodically checkpoint the open interval for crash recovery
+			if time.Since(lastCheckpoint) >= domain.CheckpointInterval {
+				lastCheckpoint = time.Now()
+				if err := state.Checkpoint(); err != nil {
+					notifyError(w, "Checkpoint error", err)
+				}
+			}
+
+			// Format elapsed according to rounding and day preferences
+			_ = elapsedBind.Set("Elapsed: " + reporting.FormatDurationWith(el, reporting.DurationFormat{
+				RoundToMinute: snap.RoundToNearestMinute,
+				ShowDays:      showDays.Load(),
+			}))
+
+			// Reflect current state label and dot
+			_ = stateBind.Set(stateText(snap.State))
+			fyne.Do(func() {
+				setStateDot(stateDot, snap.State)
+				progress.update(el, intervalTarget, snap.State == domain.InProgress)
+				updateSinceBreak(sinceBreakLabel, snap, breakReminder)
+				if snap.State == domain.InProgress && stopBtn.Disabled() && stopAllowed(state) {
+					stopBtn.Enable()
+				}
+				if now := time.Now(); !now.Before(countdown.end) {
+					refreshGoalCountdown() // a new day starts from zero
+				} else {
+					goalCountdownLabel.SetText(countdown.text(snap, now, snap.RoundToNearestMinute))
+				}
+				if dockBadgeCheck.Checked && snap.State == domain.InProgress {
+					badge.update(fmt.Sprintf("%dm", int((el+30*time.Second)/time.Minute)))
+				} else {
+					badge.update("")
+				}
+			})
+		}
+	}()
+
+	// Reports: run the report for the entered range. Also used by auto-refresh.
+	// The last range and output are kept so returning to the tab shows them again.
+	var reportRan bool
+	var lastFrom, lastTo, lastReportText, lastPresenceText string
+	runReport := func() {
+		from := strings.TrimSpace(fromEntry.Text)
+		to := strings.TrimSpace(toEntry.Text)
+		if !isYYYYMMDD(from) || !isYYYYMMDD(to) {
+			notifyError(w, "Invalid date", fmt.Errorf("dates must be YYYY-MM-DD"))
+			return
+		}
+		// A bad setting only affects its own section; the rest is still reported
+		mergeGap, mergeGapErr := domain.ParseDurationInput(mergeGapEntry.Text)
+		if mergeGapErr == nil && mergeGap%time.Minute != 0 {
+			mergeGapErr = fmt.Errorf("merge threshold must be a whole number of minutes")
+		}
+		if mergeGapErr != nil {
+			mergeGap = 0
+		}
+		exclude := excludeCheck.Selected
+		var lines []string
+		var grandTotal int64
+		if category := reportCategorySelect.Selected; category != "" && category != allCategoriesLabel {
+			days, err := reporting.CategoryDailyTotals(state.DB, category, from, to)
+			if err != nil {
+				notifyError(w, "Report error", err)
+				return
+			}
+			lines = append(lines, category+" per day")
+			for _, d := range days {
+				lines = append(lines, formatTotalLine(d.Date, d.TotalSeconds, state.RoundToNearestMinute))
+				grandTotal += d.TotalSeconds
+			}
+			if len(days) == 0 {
+				lines = append(lines, "(No results)")
+			} else {
+				lines = append(lines, formatTotalLine("Total", grandTotal, state.RoundToNearestMinute))
+			}
+		} else {
+			results, err := reporting.TotalsByCategoryMerged(state.DB, from, to, exclude, mergeGap)
+			if err != nil {
+				notifyError(w, "Report error", err)
+				return
+			}
+			for _, r := range results {
+				lines = append(lines, formatTotalLine(r.Category, r.TotalSeconds, state.RoundToNearestMinute))
+				grandTotal += r.TotalSeconds
+			}
+			if len(lines) == 0 {
+				lines = append(lines, "(No results)")
+			} else {
+				lines = append(lines, formatTotalLine("Total", grandTotal, state.RoundToNearestMinute))
+			}
+			if len(exclude) > 0 {
+				lines = append(lines, "", "Excluded: "+strings.Join(exclude, ", "))
+			}
+			if mergeGapErr != nil {
+				lines = append(lines, fmt.Sprintf("Breaks not merged: %v", mergeGapErr))
+			} else if mergeGap > 0 {
+				lines = append(lines, fmt.Sprintf("Breaks under %s merged into work", reporting.FormatDuration(mergeGap, false)))
+			}
+		}
+		reportOutput.SetText(strings.Join(lines, "\n"))
+
+		// Day × category matrix
+		dayCategoryTotals, err := reporting.TotalsByDayAndCategory(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Report error", err)
+			return
+		}
+		matrix = newDayCategoryMatrix(dayCategoryTotals)
+		matrixTable.Refresh()
+
+		// Breaks by pause reason
+		breaks, err := reporting.BreaksByReason(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Breaks error", err)
+			return
+		}
+		if len(breaks) == 0 {
+			breaksOutput.SetText("(No breaks)")
+		} else {
+			var breakLines []string
+			for _, b := range breaks {
+				reason := b.Reason
+				if reason == "" {
+					reason = "(no reason)"
+				}
+				breakLines = append(breakLines, fmt.Sprintf("%s  x%d", formatTotalLine(reason, b.TotalSeconds, state.RoundToNearestMinute), b.Count))
+			}
+			breaksOutput.SetText(strings.Join(breakLines, "\n"))
+		}
+
+		// Regular vs overtime per day
+		if overtimeThreshold, err := domain.ParseDurationInput(overtimeThresholdEntry.Text); err != nil || overtimeThreshold <= 0 {
+			overtimeOutput.SetText("(Set a positive overtime threshold to see this section)")
+		} else {
+			overtime, err := reporting.OvertimeByDay(state.DB, from, to, int64(overtimeThreshold/time.Second))
+			if err != nil {
+				notifyError(w, "Overtime error", err)
+				return
+			}
+			overtimeOutput.SetText(formatOvertime(overtime, state.RoundToNearestMinute))
+		}
+
+		// Focus vs fragmented time
+		if focusThreshold, err := domain.ParseDurationInput(focusThresholdEntry.Text); err != nil || focusThreshold <= 0 {
+			focusOutput.SetText("(Set a positive focus threshold to see this section)")
+		} else {
+			focusSecs, fragmentedSecs, err := reporting.FocusBreakdown(state.DB, from, to, focusThreshold)
+			if err != nil {
+				notifyError(w, "Focus error", err)
+				return
+			}
+			focusText := fmt.Sprintf("%s\n%s",
+				formatTotalLine("Focus", focusSecs, state.RoundToNearestMinute),
+				formatTotalLine("Fragmented", fragmentedSecs, state.RoundToNearestMinute))
+			if total := focusSecs + fragmentedSecs; total > 0 {
+				focusText += fmt.Sprintf("\n%.0f%% of worked time in intervals of %s or longer", 100*float64(focusSecs)/float64(total), reporting.FormatDuration(focusThreshold, true))
+			}
+			focusOutput.SetText(focusText)
+		}
+
+		// Totals per ticket/issue id
+		issues, err := reporting.TotalsByIssue(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Issues error", err)
+			return
+		}
+		if len(issues) == 0 {
+			issuesOutput.SetText("(No work linked to an issue)")
+		} else {
+			var issueLines []string
+			for _, t := range issues {
+				issueLines = append(issueLines, formatTotalLine(t.IssueID, t.TotalSeconds, state.RoundToNearestMinute))
+			}
+			issuesOutput.SetText(strings.Join(issueLines, "\n"))
+		}
+
+		// Totals per session label
+		labels, err := reporting.TotalsByLabel(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Labels error", err)
+			return
+		}
+		if len(labels) == 0 {
+			labelsOutput.SetText("(No labelled sessions)")
+		} else {
+			var labelLines []string
+			for _, t := range labels {
+				labelLines = append(labelLines, formatTotalLine(t.Label, t.TotalSeconds, state.RoundToNearestMinute))
+			}
+			labelsOutput.SetText(strings.Join(labelLines, "\n"))
+		}
+
+		// Billable vs non-billable split
+		billable, nonBillable, err := reporting.BillableSummary(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Billable error", err)
+			return
+		}
+		billableOutput.SetText(fmt.Sprintf("%s\n%s",
+			formatTotalLine("Billable", billable, state.RoundToNearestMinute),
+			formatTotalLine("Non-billable", nonBillable, state.RoundToNearestMinute)))
+
+		// Category totals rounded up for billing, next to the exact ones
+		if billingRoundTo, err := domain.ParseDurationInput(billingRoundEntry.Text); err != nil || billingRoundTo <= 0 {
+			billedOutput.SetText("(Set a positive billing rounding to see this section)")
+		} else {
+			billed, err := reporting.TotalsByCategoryRounded(state.DB, from, to, billingRoundTo)
+			if err != nil {
+				notifyError(w, "Billing error", err)
+				return
+			}
+			billedOutput.SetText(formatBilled(billed))
+		}
+
+		// Totals per weekday
+		weekdays, err := reporting.TotalsByWeekday(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Weekday error", err)
+			return
+		}
+		var weekdayLines []string
+		for i, secs := range weekdays {
+			weekdayLines = append(weekdayLines, formatTotalLine(reporting.WeekdayNames[i], secs, state.RoundToNearestMinute))
+		}
+		weekdayOutput.SetText(strings.Join(weekdayLines, "\n"))
+
+		// Category → description drill-down
+		breakdown, err := reporting.CategoryDescriptionBreakdown(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Breakdown error", err)
+			return
+		}
+		breakdownOutput.SetText(formatBreakdown(breakdown, state.RoundToNearestMinute))
+
+		// Longest and shortest sessions
+		longest, shortest, err := reporting.SessionExtremes(state.DB, from, to)
+		if err != nil {
+			notifyError(w, "Sessions error", err)
+			return
+		}
+		if longest.SessionID == "" {
+			sessionExtremesOutput.SetText("(No completed sessions)")
+		} else {
+			sessionExtremesOutput.SetText(fmt.Sprintf("Longest: %s (%s, started %s)\nShortest: %s (%s, started %s)",
+				reporting.FormatDuration(time.Duration(longest.TotalSeconds)*time.Second, state.RoundToNearestMinute),
+				longest.Name(), longest.StartUTC.Local().Format("2006-01-02 15:04"),
+				reporting.FormatDuration(time.Duration(shortest.TotalSeconds)*time.Second, state.RoundToNearestMinute),
+				shortest.Name(), shortest.StartUTC.Local().Format("2006-01-02 15:04")))
+		}
+
+		// Presence days
+		presenceExclude := exclude
+		if presenceIncludesExcludedCheck.Checked {
+			presenceExclude = nil
+		}
+		days, err := reporting.PresenceDays(state.DB, from, to, presenceExclude)
+		if err != nil {
+			notifyError(w, "Presence error", err)
+			return
+		}
+		if len(days) == 0 {
+			presenceOutput.SetText("Days with any work:\n(none)")
+		} else {
+			presenceOutput.SetText("Days with any work:\n" + strings.Join(days, ", "))
+		}
+		reportRan = true
+		lastFrom, lastTo = from, to
+		lastReportText, lastPresenceText = reportOutput.Text, presenceOutput.Text
+	}
+	runReportBtn = widget.NewButton("Run Report", runReport)
+
+	// Reports: optionally re-run the last report while the Reports tab is showing
+	autoRefreshCheck := widget.NewCheck("Auto-refresh every", nil)
+	autoRefreshCheck.SetChecked(storage.GetSetting(state.DB, "report_auto_refresh", "false") == "true")
+	autoRefreshCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "report_auto_refresh", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	autoRefreshEntry := widget.NewEntry()
+	autoRefreshEntry.SetText(storage.GetSetting(state.DB, "report_auto_refresh_seconds", "30"))
+	autoRefreshEntry.OnChanged = func(text string) {
+		if secs, err := strconv.Atoi(strings.TrimSpace(text)); err == nil && secs >= 5 {
+			if err := storage.SetSetting(state.DB, "report_auto_refresh_seconds", strconv.Itoa(secs)); err != nil {
+				notifyError(w, "Failed to save setting", err)
 			}
 		}
 	}
 
-	// Save scale button with message label
-	saveScaleMessage := widget.NewLabel("")
-	saveScaleBtn := widget.NewButton("Save Scale", func() {
-		val, err := strconv.ParseFloat(scaleEntry.Text, 64)
-		if err != nil || val < 0.5 || val > 3.0 {
-			notifyError(w, "Invalid scale", fmt.Errorf("scale must be between 0.5 and 3.0"))
+	// Reports: optionally confirm before leaving the tab with a range typed but not run
+	confirmLeaveReportsCheck := widget.NewCheck("Confirm before leaving with an unrun date range", nil)
+	confirmLeaveReportsCheck.SetChecked(storage.GetSetting(state.DB, "confirm_leave_reports", "false") == "true")
+	confirmLeaveReportsCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(state.DB, "confirm_leave_reports", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	// Reports: copy a Markdown summary of the range to the clipboard
+	copyMarkdownBtn := widget.NewButton("Copy as Markdown", func() {
+		from, to, ok := exportRange(w, fromEntry, toEntry)
+		if !ok {
 			return
 		}
-		if err := storage.SetSetting(state.DB, "scale", fmt.Sprintf("%.2f", val)); err != nil {
-			notifyError(w, "Failed to save scale", err)
+		var buf bytes.Buffer
+		if err := reporting.ExportMarkdown(state.DB, from, to, &buf); err != nil {
+			notifyError(w, "Markdown export error", err)
 			return
 		}
-		saveScaleMessage.SetText("Scale saved. Restart the application for changes to take effect.")
-		time.AfterFunc(5*time.Second, func() {
-			saveScaleMessage.SetText("")
-		})
+		a.Clipboard().SetContent(buf.String())
 	})
 
-	// Scale status information
-	var scaleStatusText string
-	if scaleForced {
-		scaleStatusText = fmt.Sprintf("Current scale: %.2f (forced by -scale flag)\nSaved scale: %.2f (will be used when flag is not provided)", scale, savedScale)
-	} else {
-		scaleStatusText = fmt.Sprintf("Current scale: %.2f (from database)\nNo -scale flag provided", scale)
-	}
-	scaleStatus := widget.NewLabel(scaleStatusText)
-	scaleStatus.Wrapping = fyne.TextWrapWord
-
-	// Database path (read-only)
-	dbPathLabel := widget.NewLabel(fmt.Sprintf("Database: %s", dbPath))
-	dbPathLabel.Wrapping = fyne.TextWrapWord
-
-	// --- Wire up handlers AFTER widgets exist ---
-
-	startBtn = widget.NewButton("Start Work", func() {
-		if err := state.StartWork(strings.TrimSpace(descEntry.Text), categorySelect.Selected); err != nil {
-			notifyError(w, "Start/Resume error", err)
-			return
+	// Reports: plain-text recap of the week containing From (this week if empty)
+	recapBtn := widget.NewButton("Generate Recap", func() {
+		week := strings.TrimSpace(fromEntry.Text)
+		if week == "" {
+			week = time.Now().Format("2006-01-02")
 		}
-		updateUIForState(state, startBtn, pauseBtn, stopBtn, descEntry, categorySelect)
-		refreshRecentEvents()
-		// Optional immediate state label update (not required; ticker will update in <1s)
-		switch state.CurrentState {
-		case domain.Stopped:
-			_ = stateBind.Set("State: Stopped")
-		case domain.InProgress:
-			_ = stateBind.Set("State: In-Progress")
-		case domain.Paused:
-			_ = stateBind.Set("State: Paused")
+		if !isYYYYMMDD(week) {
+			notifyError(w, "Invalid date", fmt.Errorf("dates must be YYYY-MM-DD"))
+			return
 		}
+		showWeeklyRecapDialog(a, w, state, week)
 	})
 
-	pauseBtn = widget.NewButton("Pause Work", func() {
-		if err := state.PauseWork(); err != nil {
-			notifyError(w, "Pause error", err)
+	// Reports: per-day CSV in the payroll format, with categories mapped to project codes
+	payrollBtn := widget.NewButton("Export Payroll CSV...", func() {
+		from, to, ok := exportRange(w, fromEntry, toEntry)
+		if !ok {
 			return
 		}
-		updateUIForState(state, startBtn, pauseBtn, stopBtn, descEntry, categorySelect)
-		refreshRecentEvents()
-		switch state.CurrentState {
-		case domain.Stopped:
-			_ = stateBind.Set("State: Stopped")
-		case domain.InProgress:
-			_ = stateBind.Set("State: In-Progress")
-		case domain.Paused:
-			_ = stateBind.Set("State: Paused")
-		}
+		mapping := payrollMapping(state, categoryOpts)
+		billableOnly := payrollBillableOnlyCheck.Checked
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				notifyError(w, "Export error", err)
+				return
+			}
+			if writer == nil {
+				return // cancelled
+			}
+			defer writer.Close()
+			if err := reporting.ExportPayrollCSV(state.DB, from, to, mapping, billableOnly, writer); err != nil {
+				notifyError(w, "Export error", err)
+				return
+			}
+			unmapped, err := reporting.UnmappedPayrollCategories(state.DB, from, to, mapping)
+			if err != nil {
+				notifyError(w, "Export error", err)
+				return
+			}
+			if len(unmapped) > 0 {
+				dialog.ShowInformation("Missing project codes",
+					"These categories have no payroll code and were exported under their own name:\n"+strings.Join(unmapped, ", "), w)
+			}
+		}, w)
 	})
 
-	stopBtn = widget.NewButton("Stop Work", func() {
-		if err := state.StopWork(); err != nil {
-			notifyError(w, "Stop error", err)
+	// Reports: the raw intervals, not sliced into days, for exact reconstruction
+	intervalsCSVBtn := widget.NewButton("Export Intervals CSV...", func() {
+		from, to, ok := exportRange(w, fromEntry, toEntry)
+		if !ok {
 			return
 		}
-		updateUIForState(state, startBtn, pauseBtn, stopBtn, descEntry, categorySelect)
-		refreshRecentEvents()
-		switch state.CurrentState {
-		case domain.Stopped:
-			_ = stateBind.Set("State: Stopped")
-		case domain.InProgress:
-			_ = stateBind.Set("State: In-Progress")
-		case domain.Paused:
-			_ = stateBind.Set("State: Paused")
-		}
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				notifyError(w, "Export error", err)
+				return
+			}
+			if writer == nil {
+				return // cancelled
+			}
+			defer writer.Close()
+			if err := reporting.ExportIntervalsCSV(state.DB, from, to, writer); err != nil {
+				notifyError(w, "Export error", err)
+			}
+		}, w)
 	})
 
-	// Ticker to update elapsed while InProgress (binding handles UI thread safely)
-	go func() {
-		t := time.NewTicker(1 * time.Second)
-		defer t.Stop()
-		for range t.C {
-			el := state.Elapsed()
-
-			// Format elapsed according to rounding preference
-			var txt string
-			if state.RoundToNearestMinute {
-				// Round to nearest minute
-				mins := int((el + 30*time.Second) / time.Minute)
-				txt = fmt.Sprintf("Elapsed: %dm", mins)
-			} else {
-				h := int(el / time.Hour)
-				m := int((el % time.Hour) / time.Minute)
-				s := int((el % time.Minute) / time.Second)
-				if h > 0 {
-					txt = fmt.Sprintf("Elapsed: %dh %dm %ds", h, m, s)
-				} else {
-					txt = fmt.Sprintf("Elapsed: %dm %ds", m, s)
-				}
+	// Reports: the raw events, with the version that wrote each, for auditing
+	eventsCSVBtn := widget.NewButton("Export Events CSV...", func() {
+		from, to, ok := exportRange(w, fromEntry, toEntry)
+		if !ok {
+			return
+		}
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				notifyError(w, "Export error", err)
+				return
 			}
-			_ = elapsedBind.Set(txt)
-
-			// Reflect current state label
-			switch state.CurrentState {
-			case domain.Stopped:
-				_ = stateBind.Set("State: Stopped")
-			case domain.InProgress:
-				_ = stateBind.Set("State: In-Progress")
-			case domain.Paused:
-				_ = stateBind.Set("State: Paused")
+			if writer == nil {
+				return // cancelled
 			}
-		}
-	}()
+			defer writer.Close()
+			if err := reporting.ExportEventsCSV(state.DB, from, to, writer); err != nil {
+				notifyError(w, "Export error", err)
+			}
+		}, w)
+	})
 
-	// Reports: run button handler
-	runReportBtn = widget.NewButton("Run Report", func() {
+	// Reports: reconcile rounded daily totals against the rounded range total
+	reconcileOutput := widget.NewLabel("")
+	reconcileOutput.TextStyle = fyne.TextStyle{Monospace: true}
+	reconcileBtn := widget.NewButton("Rounding Check", func() {
 		from := strings.TrimSpace(fromEntry.Text)
 		to := strings.TrimSpace(toEntry.Text)
 		if !isYYYYMMDD(from) || !isYYYYMMDD(to) {
 			notifyError(w, "Invalid date", fmt.Errorf("dates must be YYYY-MM-DD"))
 			return
 		}
-		results, err := reporting.TotalsByCategory(state.DB, from, to)
+		rec, err := reporting.ReconcileRounding(state.DB, from, to)
 		if err != nil {
 			notifyError(w, "Report error", err)
 			return
 		}
-		var lines []string
-		for _, r := range results {
-			if state.RoundToNearestMinute {
-				mins := int((time.Duration(r.TotalSeconds)*time.Second + 30*time.Second) / time.Minute)
-				lines = append(lines, fmt.Sprintf("%-14s : %3dm", r.Category, mins))
-			} else {
-				d := time.Duration(r.TotalSeconds) * time.Second
-				h := int(d / time.Hour)
-				m := int((d % time.Hour) / time.Minute)
-				s := int((d % time.Minute) / time.Second)
-				if h > 0 {
-					lines = append(lines, fmt.Sprintf("%-14s : %2dh %2dm %2ds", r.Category, h, m, s))
-				} else {
-					lines = append(lines, fmt.Sprintf("%-14s : %2dm %2ds", r.Category, m, s))
-				}
-			}
+		fmtSecs := func(secs int64, round bool) string {
+			return reporting.FormatDuration(time.Duration(secs)*time.Second, round)
 		}
-		if len(lines) == 0 {
-			lines = append(lines, "(No results)")
+		lines := []string{fmt.Sprintf("%-10s  %-12s  %s", "Date", "Exact", "Rounded")}
+		for _, d := range rec.Days {
+			lines = append(lines, fmt.Sprintf("%-10s  %-12s  %s", d.Date, fmtSecs(d.ExactSeconds, false), fmtSecs(d.RoundedSeconds, true)))
 		}
-		reportOutput.SetText(strings.Join(lines, "\n"))
+		lines = append(lines,
+			"",
+			fmt.Sprintf("Exact total:            %s", fmtSecs(rec.ExactSeconds, false)),
+			fmt.Sprintf("Sum then round:         %s", fmtSecs(rec.SumThenRoundSeconds, true)),
+			fmt.Sprintf("Round then sum (daily): %s", fmtSecs(rec.RoundThenSumSeconds, true)),
+		)
+		if diff := rec.RoundThenSumSeconds - rec.SumThenRoundSeconds; diff != 0 {
+			lines = append(lines, fmt.Sprintf("Discrepancy:            %+dm", diff/60))
+		}
+		reconcileOutput.SetText(strings.Join(lines, "\n"))
+	})
 
-		// Presence days
-		days, err := reporting.PresenceDays(state.DB, from, to)
+	// Reports: untracked gaps within the workday for a single day
+	gapsDayEntry := widget.NewEntry()
+	gapsDayEntry.SetText(storage.ReportDate(state.DB, time.Now()))
+	workdayStartEntry := widget.NewEntry()
+	workdayStartEntry.SetText(storage.GetSetting(state.DB, "workday_start", "09:00"))
+	workdayEndEntry := widget.NewEntry()
+	workdayEndEntry.SetText(storage.GetSetting(state.DB, "workday_end", "17:00"))
+	gapsOutput := widget.NewLabel("")
+	gapsOutput.Wrapping = fyne.TextWrapWord
+	findGapsBtn := widget.NewButton("Find Gaps", func() {
+		day := strings.TrimSpace(gapsDayEntry.Text)
+		if !isYYYYMMDD(day) {
+			notifyError(w, "Invalid date", fmt.Errorf("day must be YYYY-MM-DD"))
+			return
+		}
+		start := strings.TrimSpace(workdayStartEntry.Text)
+		end := strings.TrimSpace(workdayEndEntry.Text)
+		gaps, err := reporting.UntrackedGaps(state.DB, day, start, end)
 		if err != nil {
-			notifyError(w, "Presence error", err)
+			notifyError(w, "Gaps error", err)
 			return
 		}
-		if len(days) == 0 {
-			presenceOutput.SetText("Days with any work:\n(none)")
-		} else {
-			presenceOutput.SetText("Days with any work:\n" + strings.Join(days, ", "))
+		// Remember the workday window once it has proven valid
+		if err := storage.SetSetting(state.DB, "workday_start", start); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		if err := storage.SetSetting(state.DB, "workday_end", end); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		if len(gaps) == 0 {
+			gapsOutput.SetText("(No untracked time)")
+			return
+		}
+		var lines []string
+		var total int64
+		for _, g := range gaps {
+			total += g.Seconds()
+			lines = append(lines, fmt.Sprintf("%s – %s  %s",
+				g.StartUTC.Local().Format("15:04"), g.EndUTC.Local().Format("15:04"),
+				reporting.FormatDuration(time.Duration(g.Seconds())*time.Second, state.RoundToNearestMinute)))
+		}
+		lines = append(lines, fmt.Sprintf("Untracked total: %s", reporting.FormatDuration(time.Duration(total)*time.Second, state.RoundToNearestMinute)))
+		gapsOutput.SetText(strings.Join(lines, "\n"))
+	})
+
+	// Reports: chronological diary of one day's intervals
+	dayLogEntry := widget.NewEntry()
+	dayLogEntry.SetText(time.Now().Format("2006-01-02"))
+	dayLogOutput := widget.NewLabel("")
+	dayLogOutput.TextStyle = fyne.TextStyle{Monospace: true}
+	dayLogOutput.Wrapping = fyne.TextWrapWord
+	showDayLogBtn := widget.NewButton("Show Log", func() {
+		day := strings.TrimSpace(dayLogEntry.Text)
+		if !isYYYYMMDD(day) {
+			notifyError(w, "Invalid date", fmt.Errorf("day must be YYYY-MM-DD"))
+			return
+		}
+		entries, err := reporting.DayLog(state.DB, day)
+		if err != nil {
+			notifyError(w, "Day log error", err)
+			return
 		}
+		if len(entries) == 0 {
+			dayLogOutput.SetText("(Nothing tracked)")
+			return
+		}
+		// Show times in the zone the day was cut in
+		loc := storage.ReportLocation(state.DB)
+		var lines []string
+		for _, e := range entries {
+			end := e.EndUTC.In(loc).Format("15:04")
+			if e.Open {
+				end = "now  "
+			}
+			lines = append(lines, fmt.Sprintf("%s – %s  %-8s %-14s %s",
+				e.StartUTC.In(loc).Format("15:04"), end,
+				reporting.FormatDuration(time.Duration(e.Seconds)*time.Second, state.RoundToNearestMinute),
+				e.Category, e.Description))
+		}
+		dayLogOutput.SetText(strings.Join(lines, "\n"))
 	})
 
 	// Layout panes - Track tab with recent events
 	controlsTop := container.NewVBox(
+		workBanner,
 		widget.NewLabel("Work Details"),
 		descEntry,
+		issueEntry,
+		labelEntry,
 		categorySelect,
-		container.NewHBox(startBtn, pauseBtn, stopBtn),
-		container.NewHBox(stateLabel, widget.NewSeparator(), elapsedLabel),
+		billableCheck,
+		container.NewHBox(startBtn, pauseBtn, stopBtn, adjustBtn, continueBtn, logBlockBtn),
+		quickStartBox,
+		autoPauseNext.box,
+		container.NewHBox(
+			container.NewCenter(container.NewGridWrap(fyne.NewSize(12, 12), stateDot)),
+			stateLabel, widget.NewSeparator(), elapsedLabel, sinceBreakLabel,
+			progress,
+		),
+		container.NewBorder(nil, nil, widget.NewLabel("Last 7 days:"), nil, weekSparkline),
+		goalStreakLabel,
+		goalCountdownLabel,
+		categoryGoalsBox,
 	)
 
 	recentEventsSection := container.NewBorder(
-		widget.NewLabel("Recent Activity"),
+		widget.NewLabel("Recent Activity (select a row for session details)"),
 		nil, nil, nil,
 		recentEventsList,
 	)
@@ -369,12 +2004,66 @@ LIMIT 5;
 			container.NewVBox(widget.NewLabel("From"), fromEntry),
 			container.NewVBox(widget.NewLabel("To"), toEntry),
 		),
-		runReportBtn,
+		container.NewBorder(nil, nil, widget.NewLabel("Category:"), nil, reportCategorySelect),
+		widget.NewAccordion(
+			widget.NewAccordionItem("Exclude categories",
+				container.NewVBox(excludeCheck, presenceIncludesExcludedCheck),
+			),
+			widget.NewAccordionItem("Merge short breaks",
+				container.NewVBox(
+					container.NewBorder(nil, nil, widget.NewLabel("Merge breaks shorter than:"), nil, mergeGapEntry),
+					mergeGapHelp,
+				),
+			),
+		),
+		container.NewHBox(runReportBtn, copyMarkdownBtn, recapBtn, payrollBtn, payrollBillableOnlyCheck, intervalsCSVBtn, eventsCSVBtn, reconcileBtn),
+		container.NewHBox(autoRefreshCheck, autoRefreshEntry, widget.NewLabel("seconds (min 5)")),
+		confirmLeaveReportsCheck,
 		widget.NewSeparator(),
 		widget.NewLabel("Totals per category"),
 		reportScroll,
 		widget.NewLabel("Presence"),
 		presenceScroll,
+		widget.NewLabel("Breaks"),
+		breaksOutput,
+		widget.NewLabel("Focus"),
+		container.NewBorder(nil, nil, widget.NewLabel("Focus intervals are at least:"), nil, focusThresholdEntry),
+		focusOutput,
+		widget.NewLabel("Overtime"),
+		container.NewBorder(nil, nil, widget.NewLabel("Regular hours per day:"), nil, overtimeThresholdEntry),
+		overtimeOutput,
+		widget.NewLabel("Issues"),
+		issuesOutput,
+		widget.NewLabel("Labels"),
+		labelsOutput,
+		widget.NewLabel("Billable"),
+		billableOutput,
+		widget.NewLabel("Billed (category totals rounded up)"),
+		container.NewBorder(nil, nil, widget.NewLabel("Round each category up to:"), nil, billingRoundEntry),
+		billedOutput,
+		widget.NewLabel("By category and description"),
+		breakdownOutput,
+		widget.NewLabel("By weekday"),
+		weekdayOutput,
+		widget.NewLabel("Sessions"),
+		sessionExtremesOutput,
+		widget.NewLabel("Day × category"),
+		matrixArea,
+		widget.NewLabel("Monthly budgets (this month)"),
+		monthlyBudgetsBox,
+		reconcileOutput,
+		widget.NewSeparator(),
+		widget.NewLabel("Untracked gaps"),
+		container.NewHBox(
+			widget.NewLabel("Day:"), gapsDayEntry,
+			widget.NewLabel("Workday:"), workdayStartEntry, widget.NewLabel("to"), workdayEndEntry,
+			findGapsBtn,
+		),
+		gapsOutput,
+		widget.NewSeparator(),
+		widget.NewLabel("Day Log"),
+		container.NewHBox(widget.NewLabel("Day:"), dayLogEntry, showDayLogBtn),
+		dayLogOutput,
 	)
 
 	// Settings tab layout
@@ -384,7 +2073,51 @@ LIMIT 5;
 		
 		widget.NewLabel("Display Options"),
 		exactDurationsCheck,
+		roundingPreviewLabel,
+		durationDaysCheck,
+		alwaysOnTopCheck,
+		dockBadgeCheck,
+		workBannerCheck,
+		pauseReasonCheck,
+		stopReasonCheck,
+		clearOnStopCheck,
+		draftCheck,
+		warnEmptyDescCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("When closing during work:"), nil, closeActionSelect),
+		container.NewBorder(nil, nil, widget.NewLabel("Log level:"), nil, logLevelSelect),
+		container.NewBorder(nil, nil, widget.NewLabel("Auto-pause at:"), autoPauseStatus, autoPauseEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Minimum interval:"), widget.NewLabel("seconds"), minIntervalEntry),
+		minIntervalHelp,
+		container.NewBorder(nil, nil, widget.NewLabel("Allow Stop after:"), widget.NewLabel("seconds"), minStopEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Stale restore after:"), widget.NewLabel("hours"), staleRestoreEntry),
+		staleRestoreHelp,
 		
+		widget.NewSeparator(),
+		widget.NewLabel("Quick Start Buttons"),
+		quickStartCheck,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Recent Activity"),
+		recentEventsSettings,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Report Timezone"),
+		container.NewBorder(nil, nil, widget.NewLabel("Zone:"), reportTZStatus, reportTZEntry),
+		reportTZHelp,
+		container.NewBorder(nil, nil, widget.NewLabel("Day starts at:"), dayBoundaryStatus, dayBoundaryEntry),
+		dayBoundaryHelp,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Goals"),
+		container.NewBorder(nil, nil, widget.NewLabel("Daily goal:"), nil, dailyGoalEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Weekly goal:"), nil, weeklyGoalEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Interval target:"), nil, intervalTargetEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Break reminder after:"), nil, breakReminderEntry),
+		widget.NewLabel("Daily goal per category"),
+		categoryGoalsForm,
+		widget.NewLabel("Monthly budget per category"),
+		monthlyBudgetsForm,
+
 		widget.NewSeparator(),
 		widget.NewLabel("UI Scale (0.5 - 3.0)"),
 		scaleStatus,
@@ -393,17 +2126,119 @@ LIMIT 5;
 		saveScaleBtn,
 		saveScaleMessage,
 		
+		widget.NewSeparator(),
+		widget.NewLabel("Launch"),
+		autoStartCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("Category:"), nil, autoStartCategorySelect),
+		autoResumeCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("Only if paused within:"), nil, autoResumeWindowEntry),
+		startMinimizedCheck,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Payroll Project Codes"),
+		payrollCodesForm,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Status File"),
+		statusFileCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("Path:"), nil, statusFileEntry),
+
+		widget.NewSeparator(),
+		widget.NewLabel("Scheduled Export"),
+		newScheduledExportSettings(w, state.DB),
+
+		widget.NewSeparator(),
+		widget.NewLabel("Webhook"),
+		container.NewBorder(nil, nil, widget.NewLabel("URL:"), testWebhookBtn, webhookEntry),
+
+		widget.NewSeparator(),
+		widget.NewLabel("History"),
+		lifetimeLabel,
+		usageView,
+
 		widget.NewSeparator(),
 		widget.NewLabel("Database Location"),
 		dbPathLabel,
+		widget.NewButton("About Timeclock...", func() { showAboutDialog(w, state, dbPath, appVersion) }),
+		mergeDBBtn,
+		mergeSkipOverlapsCheck,
+		container.NewBorder(nil, nil, nil, importEntriesBtn, importFormatSelect),
+		importSkipOverlapsCheck,
+		container.NewHBox(exportSettingsBtn, importSettingsBtn),
+		rebuildDaysBtn,
+		optimizeDBBtn,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Trash"),
+		trashView,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Re-categorize Past Work"),
+		container.NewGridWithColumns(2, recatOldSelect, recatNewSelect, recatFromEntry, recatToEntry),
+		recatBtn,
+
+		widget.NewSeparator(),
+		widget.NewLabel("Rename Category Everywhere"),
+		container.NewGridWithColumns(2, renameOldEntry, renameNewEntry),
+		renameCategoryBtn,
 	)
 
+	reportsTab := container.NewTabItem("Reports", container.NewVScroll(reports))
 	tabs := container.NewAppTabs(
 		container.NewTabItem("Track", controls),
-		container.NewTabItem("Reports", reports),
+		reportsTab,
 		container.NewTabItem("Settings", settings),
 	)
 	tabs.SetTabLocation(container.TabLocationTop)
+	tabs.OnSelected = func(item *container.TabItem) {
+		if item == reportsTab && reportRan {
+			reportOutput.SetText(lastReportText)
+			presenceOutput.SetText(lastPresenceText)
+		}
+	}
+	tabs.OnUnselected = func(item *container.TabItem) {
+		if item != reportsTab || !confirmLeaveReportsCheck.Checked {
+			return
+		}
+		from, to := strings.TrimSpace(fromEntry.Text), strings.TrimSpace(toEntry.Text)
+		if (from == "" && to == "") || (from == lastFrom && to == lastTo) {
+			return
+		}
+		dialog.ShowConfirm("Leave Reports?",
+			"The date range you entered has not been run yet. Leave the Reports tab anyway?",
+			func(leave bool) {
+				if !leave {
+					tabs.Select(reportsTab)
+				}
+			}, w)
+	}
+
+	// Auto-refresh the Reports tab; only queries while that tab is visible
+	go func() {
+		t := time.NewTicker(1 * time.Second)
+		defer t.Stop()
+		lastRefresh := time.Now()
+		for {
+			select {
+			case <-tickersDone:
+				return
+			case <-t.C:
+			}
+			fyne.Do(func() {
+				secs, err := strconv.Atoi(strings.TrimSpace(autoRefreshEntry.Text))
+				if err != nil || secs < 5 {
+					return
+				}
+				if !autoRefreshCheck.Checked || !reportRan || tabs.Selected() != reportsTab {
+					return
+				}
+				if time.Since(lastRefresh) >= time.Duration(secs)*time.Second {
+					lastRefresh = time.Now()
+					runReport()
+				}
+			})
+		}
+	}()
 
 	// Status line at bottom
 	statusLine := container.NewBorder(
@@ -421,28 +2256,135 @@ LIMIT 5;
 		tabs,
 	)
 
+	// Auto-start tracking the default category when nothing was restored,
+	// taking over any saved description draft
+	if autoStartCheck.Checked && autoStartCategorySelect.Selected != "" && state.CurrentState == domain.Stopped {
+		if err := state.StartWork(strings.TrimSpace(descEntry.Text), autoStartCategorySelect.Selected, "", ""); err != nil {
+			notifyError(w, "Auto-start error", err)
+		} else {
+			draft.clear()
+			selectCategory(categorySelect, categoryOpts, state.Category)
+		}
+	}
+
+	// Resume a recently paused session when quitting was just a break
+	if autoResumeCheck.Checked && state.CurrentState == domain.Paused {
+		window, err := domain.ParseDurationInput(storage.GetSetting(state.DB, "auto_resume_window", defaultAutoResumeWindow))
+		if err != nil || window <= 0 {
+			window, _ = domain.ParseDurationInput(defaultAutoResumeWindow)
+		}
+		info, err := storage.GetSession(state.DB, state.SessionID)
+		if err != nil {
+			notifyError(w, "Auto-resume error", err)
+		} else if time.Since(info.LastEventUTC) <= window {
+			if err := state.StartWork("", "", "", ""); err != nil {
+				notifyError(w, "Auto-resume error", err)
+			}
+		}
+	}
+
 	// Initial UI state
-	updateUIForState(state, startBtn, pauseBtn, stopBtn, descEntry, categorySelect)
+	updateUIForState(state, startBtn, pauseBtn, stopBtn, descEntry, issueEntry, labelEntry, categorySelect, stateDot, workBanner)
+	refreshQuickStart()
 	refreshRecentEvents()
+	refreshGoalStreak()
+	refreshCategoryGoals()
+	refreshMonthlyBudgets()
+	refreshGoalCountdown()
+	refreshLifetime()
+
+	a.Lifecycle().SetOnStarted(func() {
+		// The native window only exists once the app is running
+		applyAlwaysOnTop()
+
+		// Let the user decide what to do with a session that was interrupted
+		if state.RestoredInProgress {
+			showRestoreDialog(w, state, refreshAfterTransition)
+		}
+	})
 
 	w.SetContent(mainContent)
 	w.Resize(fyne.NewSize(700, 500))
+
+	// Command palette (Ctrl+K, Cmd+K on macOS); commands go through the same
+	// handlers as the buttons, so they respect the current state
+	showReport := func(from, to time.Time) func(string) {
+		return func(string) {
+			fromEntry.SetText(from.Format("2006-01-02"))
+			toEntry.SetText(to.Format("2006-01-02"))
+			tabs.Select(reportsTab)
+			runReport()
+		}
+	}
+	paletteCommands := func() []paletteCommand {
+		now := time.Now()
+		monday := now.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+		monthStart := now.AddDate(0, 0, 1-now.Day())
+		return []paletteCommand{
+			{name: "start", argHint: "category", available: func() bool { return state.Snapshot().State == domain.Stopped }, run: func(arg string) {
+				if arg != "" {
+					category, ok := matchCategory(categoryOpts, arg)
+					if !ok {
+						dialog.ShowInformation("Start", fmt.Sprintf("No category matches %q.", arg), w)
+						return
+					}
+					selectCategory(categorySelect, categoryOpts, category)
+				}
+				startBtn.OnTapped()
+			}},
+			{name: "resume", available: func() bool { return state.Snapshot().State == domain.Paused }, run: func(string) { startBtn.OnTapped() }},
+			{name: "pause", available: func() bool { return !pauseBtn.Disabled() }, run: func(string) { pauseBtn.OnTapped() }},
+			{name: "stop", available: func() bool { return !stopBtn.Disabled() }, run: func(string) { stopBtn.OnTapped() }},
+			{name: "report today", run: showReport(now, now)},
+			{name: "report this week", run: showReport(monday, monday.AddDate(0, 0, 6))},
+			{name: "report last week", run: showReport(monday.AddDate(0, 0, -7), monday.AddDate(0, 0, -1))},
+			{name: "report this month", run: showReport(monthStart, monthStart.AddDate(0, 1, -1))},
+		}
+	}
+	w.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyK, Modifier: fyne.KeyModifierShortcutDefault}, func(fyne.Shortcut) {
+		showCommandPalette(w, paletteCommands())
+	})
 	// Optional: this code is run before the window closes.
-	w.SetCloseIntercept(func() {
-		// This example code checks the state of work, and if work is in-progress it prints a warning.
-		if state.CurrentState == domain.InProgress {
-			fmt.Println("!! WARNING - Work is In-Progress and being tracked even if Timeclock is not running.")
+	closeApp := func() {
+		// Mark the exit as clean so a running interval isn't treated as a crash
+		if err := state.Shutdown(); err != nil {
+			notifyError(w, "Shutdown error", err)
 		}
-		
-		// Actually close the window
+
+		// Actually close the window, once nothing is left updating it
+		stopTickers()
 		w.Close()
+	}
+	w.SetCloseIntercept(func() {
+		// Work in progress keeps counting while closed, so decide what to do with it
+		if state.Snapshot().State == domain.InProgress {
+			if action := closeActionSelect.Selected; action != closeActionAsk {
+				applyCloseAction(w, state, action)
+			} else {
+				showCloseDialog(w, state, closeApp)
+				return
+			}
+		}
+		closeApp()
 	})
 
+	// Start minimized: keep the window hidden and offer it from the system tray.
+	// Without tray support there would be no way back, so show it normally.
+	if desk, ok := a.(desktop.App); ok && startMinimizedCheck.Checked {
+		desk.SetSystemTrayMenu(fyne.NewMenu("Timeclock",
+			fyne.NewMenuItem("Show Timeclock", w.Show),
+		))
+		a.Run()
+		return
+	}
+
 	w.ShowAndRun()
 }
 
 // updateUIForState keeps its original signature (no bindings here)
-func updateUIForState(state *domain.AppState, startBtn, pauseBtn, stopBtn *widget.Button, descEntry *widget.Entry, category *widget.Select) {
+func updateUIForState(state *domain.AppState, startBtn, pauseBtn, stopBtn *widget.Button, descEntry, issueEntry, labelEntry *widget.Entry, category *widget.Select, dot *canvas.Circle, banner *widget.Label) {
+	setStateDot(dot, state.CurrentState)
+	updateWorkBanner(banner, state)
 	switch state.CurrentState {
 	case domain.Stopped:
 		startBtn.Enable()
@@ -451,14 +2393,23 @@ func updateUIForState(state *domain.AppState, startBtn, pauseBtn, stopBtn *widge
 		stopBtn.Disable()
 
 		descEntry.Enable()
+		issueEntry.Enable()
+		labelEntry.Enable()
 		category.Enable()
 	case domain.InProgress:
 		startBtn.Disable()
 		startBtn.SetText("Start Work")
 		pauseBtn.Enable()
-		stopBtn.Enable()
+		// The ticker enables Stop once the minimum has passed
+		if stopAllowed(state) {
+			stopBtn.Enable()
+		} else {
+			stopBtn.Disable()
+		}
 
 		descEntry.Disable()
+		issueEntry.Disable()
+		labelEntry.Disable()
 		category.Disable()
 	case domain.Paused:
 		startBtn.Enable()
@@ -467,13 +2418,192 @@ func updateUIForState(state *domain.AppState, startBtn, pauseBtn, stopBtn *widge
 		stopBtn.Enable()
 
 		descEntry.Disable()
+		issueEntry.Disable()
+		labelEntry.Disable()
 		category.Disable()
 	}
 }
 
+// stopAllowed reports whether the running interval has reached the
+// "min_stop_seconds" setting (0, the default, means no minimum).
+func stopAllowed(state *domain.AppState) bool {
+	minSeconds, err := strconv.Atoi(storage.GetSetting(state.DB, "min_stop_seconds", "0"))
+	if err != nil || minSeconds <= 0 {
+		return true
+	}
+	return state.Elapsed() >= time.Duration(minSeconds)*time.Second
+}
+
+// stateText returns the status label for a state.
+func stateText(st domain.State) string {
+	switch st {
+	case domain.InProgress:
+		return "State: In-Progress"
+	case domain.Paused:
+		return "State: Paused"
+	default:
+		return "State: Stopped"
+	}
+}
+
+// stateColor is the indicator color for a state: green running, amber paused,
+// grey stopped.
+func stateColor(st domain.State) color.Color {
+	switch st {
+	case domain.InProgress:
+		return color.NRGBA{R: 0x2e, G: 0xb8, B: 0x4f, A: 0xff}
+	case domain.Paused:
+		return color.NRGBA{R: 0xf0, G: 0xa8, B: 0x20, A: 0xff}
+	default:
+		return color.NRGBA{R: 0x90, G: 0x90, B: 0x90, A: 0xff}
+	}
+}
+
+// setStateDot recolors the state indicator, refreshing only on change.
+func setStateDot(dot *canvas.Circle, st domain.State) {
+	c := stateColor(st)
+	if dot.FillColor == c {
+		return
+	}
+	dot.FillColor = c
+	dot.Refresh()
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5 MiB".
+func formatBytes(n int64) string {
+	if n < 0 {
+		n = 0
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// pluralWeeks renders a week count, e.g. "1 week" or "4 weeks".
+func pluralWeeks(n int) string {
+	if n == 1 {
+		return "1 week"
+	}
+	return fmt.Sprintf("%d weeks", n)
+}
+
+// formatTotalLine renders one "Category : duration" row of the totals report.
+func formatTotalLine(label string, totalSeconds int64, roundToMinute bool) string {
+	if roundToMinute {
+		mins := int((time.Duration(totalSeconds)*time.Second + 30*time.Second) / time.Minute)
+		return fmt.Sprintf("%-14s : %3dm", label, mins)
+	}
+	d := time.Duration(totalSeconds) * time.Second
+	h := int(d / time.Hour)
+	m := int((d % time.Hour) / time.Minute)
+	s := int((d % time.Minute) / time.Second)
+	if h > 0 {
+		return fmt.Sprintf("%-14s : %2dh %2dm %2ds", label, h, m, s)
+	}
+	return fmt.Sprintf("%-14s : %2dm %2ds", label, m, s)
+}
+
+// formatBilled renders a Category / Exact / Billed table with the rounding
+// difference per category and a totals row. Exact times keep their seconds so
+// the difference is visible.
+func formatBilled(totals []reporting.CategoryRounding) string {
+	if len(totals) == 0 {
+		return "(No work in range)"
+	}
+	dur := func(secs int64) string {
+		return reporting.FormatDuration(time.Duration(secs)*time.Second, false)
+	}
+	row := "%-16s  %12s  %12s  %10s"
+	lines := []string{fmt.Sprintf(row, "Category", "Exact", "Billed", "Added")}
+	var exact, billed int64
+	for _, t := range totals {
+		lines = append(lines, fmt.Sprintf(row, t.Category, dur(t.ExactSeconds), dur(t.RoundedSeconds), dur(t.RoundedSeconds-t.ExactSeconds)))
+		exact += t.ExactSeconds
+		billed += t.RoundedSeconds
+	}
+	lines = append(lines, fmt.Sprintf(row, "Total", dur(exact), dur(billed), dur(billed-exact)))
+	return strings.Join(lines, "\n")
+}
+
+// formatOvertime renders days as a Date / Regular / Overtime / Total table
+// followed by a totals row.
+func formatOvertime(days []reporting.DayOvertime, roundToMinute bool) string {
+	if len(days) == 0 {
+		return "(No work in range)"
+	}
+	dur := func(secs int64) string {
+		return reporting.FormatDuration(time.Duration(secs)*time.Second, roundToMinute)
+	}
+	lines := []string{fmt.Sprintf("%-10s  %10s  %10s  %10s", "Date", "Regular", "Overtime", "Total")}
+	var regular, overtime, total int64
+	for _, d := range days {
+		lines = append(lines, fmt.Sprintf("%-10s  %10s  %10s  %10s", d.Date, dur(d.RegularSeconds), dur(d.OvertimeSeconds), dur(d.TotalSeconds)))
+		regular += d.RegularSeconds
+		overtime += d.OvertimeSeconds
+		total += d.TotalSeconds
+	}
+	lines = append(lines, fmt.Sprintf("%-10s  %10s  %10s  %10s", "Total", dur(regular), dur(overtime), dur(total)))
+	return strings.Join(lines, "\n")
+}
+
+// formatBreakdown renders each category's total followed by its descriptions,
+// indented, with the largest categories first.
+func formatBreakdown(breakdown map[string][]reporting.DescriptionTotal, roundToMinute bool) string {
+	if len(breakdown) == 0 {
+		return "(No data)"
+	}
+	totals := map[string]int64{}
+	var categories []string
+	for cat, descs := range breakdown {
+		categories = append(categories, cat)
+		for _, d := range descs {
+			totals[cat] += d.TotalSeconds
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if totals[categories[i]] != totals[categories[j]] {
+			return totals[categories[i]] > totals[categories[j]]
+		}
+		return categories[i] < categories[j]
+	})
+	var lines []string
+	for _, cat := range categories {
+		lines = append(lines, formatTotalLine(cat, totals[cat], roundToMinute))
+		for _, d := range breakdown[cat] {
+			lines = append(lines, "  "+formatTotalLine(d.Description, d.TotalSeconds, roundToMinute))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func notifyError(w fyne.Window, title string, err error) {
 	// Minimal notify; Phase 3 can add dialog boxes.
-	fmt.Printf("%s: %v\n", title, err)
+	logging.Errorf("%s: %v", title, err)
+}
+
+// exportRange reads the Reports tab's From/To for an export. Instead of
+// exporting, it prompts when either is empty or invalid, so an export always
+// covers exactly the range on screen.
+func exportRange(w fyne.Window, fromEntry, toEntry *widget.Entry) (from, to string, ok bool) {
+	from, to = strings.TrimSpace(fromEntry.Text), strings.TrimSpace(toEntry.Text)
+	switch {
+	case from == "" || to == "":
+		dialog.ShowInformation("Choose a date range", "Enter From and To (YYYY-MM-DD) above to choose the range to export.", w)
+	case !isYYYYMMDD(from) || !isYYYYMMDD(to):
+		dialog.ShowError(fmt.Errorf("dates must be YYYY-MM-DD"), w)
+	case to < from:
+		dialog.ShowError(fmt.Errorf("the To date %s is before the From date %s", to, from), w)
+	default:
+		return from, to, true
+	}
+	return "", "", false
 }
 
 // isYYYYMMDD validates a date string in the form YYYY-MM-DD.
@@ -502,3 +2632,47 @@ func isYYYYMMDD(s string) bool {
 	return true
 }
 
+// updateSinceBreak shows how long work has run since the last pause or resume,
+// which is the current interval's elapsed time. It turns red once it reaches
+// reminder (0 for never) and is blank unless work is in progress.
+func updateSinceBreak(label *widget.Label, snap domain.StateSnapshot, reminder time.Duration) {
+	if snap.State != domain.InProgress {
+		label.SetText("")
+		return
+	}
+	importance := widget.MediumImportance
+	if reminder > 0 && snap.Elapsed >= reminder {
+		importance = widget.DangerImportance
+	}
+	if label.Importance != importance {
+		label.Importance = importance
+		label.Refresh()
+	}
+	label.SetText("Since break: " + reporting.FormatDuration(snap.Elapsed, true))
+}
+
+// validateScale checks a UI scale typed into the Settings entry.
+func validateScale(text string) error {
+	val, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return fmt.Errorf("scale must be a number")
+	}
+	if val < 0.5 || val > 3.0 {
+		return fmt.Errorf("scale must be between 0.5 and 3.0")
+	}
+	return nil
+}
+
+// roundingPreviewSample is the duration used to illustrate the two display formats.
+const roundingPreviewSample = 2*time.Hour + 3*time.Minute + 42*time.Second
+
+// roundingPreview describes how durations are shown with the exact-durations
+// toggle in the given position, using the same formatter as the rest of the UI.
+func roundingPreview(exact bool) string {
+	exactText := reporting.FormatDuration(roundingPreviewSample, false)
+	roundedText := reporting.FormatDuration(roundingPreviewSample, true)
+	if exact {
+		return fmt.Sprintf("Example: %s is shown as %s (rounded: %s). Display only; recorded times are always exact.", exactText, exactText, roundedText)
+	}
+	return fmt.Sprintf("Example: %s is shown as %s. Display only; recorded times are always exact.", exactText, roundedText)
+}
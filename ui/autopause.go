@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// nextAutoPause returns when the "auto_pause_time" setting next fires after now,
+// and whether that trigger has been snoozed. ok is false when no auto-pause is
+// scheduled. A snooze names the date of the trigger it skips
+// ("auto_pause_snoozed_date"), so it never outlives that day.
+func nextAutoPause(db *sql.DB, now time.Time) (at time.Time, snoozed, ok bool) {
+	t, err := time.Parse("15:04", storage.GetSetting(db, "auto_pause_time", ""))
+	if err != nil {
+		return time.Time{}, false, false
+	}
+	at = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !now.Before(at.Add(time.Minute)) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, autoPauseSnoozed(db, at.Format("2006-01-02")), true
+}
+
+// autoPauseSnoozed reports whether the auto-pause on the local date day is skipped.
+func autoPauseSnoozed(db *sql.DB, day string) bool {
+	return storage.GetSetting(db, "auto_pause_snoozed_date", "") == day
+}
+
+// autoPauseIndicator shows the next scheduled auto-pause on the Track tab with
+// a button to snooze it, or to take the snooze back.
+type autoPauseIndicator struct {
+	w      fyne.Window
+	db     *sql.DB
+	label  *widget.Label
+	button *widget.Button
+	box    *fyne.Container
+}
+
+func newAutoPauseIndicator(w fyne.Window, db *sql.DB) *autoPauseIndicator {
+	ind := &autoPauseIndicator{w: w, db: db, label: widget.NewLabel("")}
+	ind.button = widget.NewButton("Snooze", ind.toggle)
+	ind.box = container.NewHBox(ind.label, ind.button)
+	ind.refresh(time.Now())
+	return ind
+}
+
+// toggle snoozes the next trigger, or clears the snooze if it is already set.
+func (ind *autoPauseIndicator) toggle() {
+	at, snoozed, ok := nextAutoPause(ind.db, time.Now())
+	if !ok {
+		return
+	}
+	value := at.Format("2006-01-02")
+	if snoozed {
+		value = ""
+	}
+	if err := storage.SetSetting(ind.db, "auto_pause_snoozed_date", value); err != nil {
+		notifyError(ind.w, "Failed to save setting", err)
+	}
+	ind.refresh(time.Now())
+}
+
+// refresh updates the indicator for now, hiding it when nothing is scheduled.
+func (ind *autoPauseIndicator) refresh(now time.Time) {
+	at, snoozed, ok := nextAutoPause(ind.db, now)
+	if !ok {
+		ind.box.Hide()
+		return
+	}
+	day := "today"
+	if at.Day() != now.Day() {
+		day = "tomorrow"
+	}
+	if snoozed {
+		ind.label.SetText(fmt.Sprintf("Auto-pause %s at %s is snoozed", day, at.Format("15:04")))
+		ind.button.SetText("Cancel Snooze")
+	} else {
+		ind.label.SetText(fmt.Sprintf("Auto-pause: %s at %s", day, at.Format("15:04")))
+		ind.button.SetText("Snooze")
+	}
+	ind.box.Show()
+}
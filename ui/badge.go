@@ -0,0 +1,17 @@
+package ui
+
+// dockBadge keeps the dock/taskbar badge in sync with the elapsed time. Only
+// changes reach the platform, since the ticker calls update every second.
+type dockBadge struct {
+	shown string
+}
+
+// update shows text on the badge; "" clears it. It is a no-op where
+// dockBadgeSupported is false.
+func (b *dockBadge) update(text string) {
+	if !dockBadgeSupported || text == b.shown {
+		return
+	}
+	b.shown = text
+	setNativeDockBadge(text)
+}
@@ -0,0 +1,29 @@
+package ui
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework AppKit
+
+#include <stdlib.h>
+#import <AppKit/AppKit.h>
+
+// timeclockSetBadge sets the dock tile badge on the main thread; an empty label
+// removes it. label is freed here.
+static void timeclockSetBadge(char *label) {
+	@autoreleasepool {
+		NSString *s = [NSString stringWithUTF8String:label];
+		free(label);
+		dispatch_async(dispatch_get_main_queue(), ^{
+			[[NSApp dockTile] setBadgeLabel:([s length] > 0 ? s : nil)];
+		});
+	}
+}
+*/
+import "C"
+
+// dockBadgeSupported reports whether setNativeDockBadge does anything.
+const dockBadgeSupported = true
+
+func setNativeDockBadge(text string) {
+	C.timeclockSetBadge(C.CString(text))
+}
@@ -0,0 +1,9 @@
+//go:build !darwin
+
+package ui
+
+// Fyne has no badge API and only the macOS dock is wired up natively, so the
+// badge is a no-op elsewhere.
+const dockBadgeSupported = false
+
+func setNativeDockBadge(text string) {}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// workBannerDescriptionLength is how many characters of the description the
+// work banner shows before truncating.
+const workBannerDescriptionLength = 60
+
+// newWorkBanner returns the large label naming the work being tracked. It is
+// sized by the theme, so it follows the UI scale.
+func newWorkBanner() *widget.Label {
+	banner := widget.NewLabel("")
+	banner.SizeName = theme.SizeNameHeadingText
+	banner.TextStyle = fyne.TextStyle{Bold: true}
+	banner.Truncation = fyne.TextTruncateEllipsis
+	banner.Hide()
+	return banner
+}
+
+// updateWorkBanner shows the current category and description while a session
+// is in progress or paused and the "show_work_banner" setting is on, and hides
+// the banner otherwise.
+func updateWorkBanner(banner *widget.Label, state *domain.AppState) {
+	snap := state.Snapshot()
+	if snap.State == domain.Stopped || storage.GetSetting(state.DB, "show_work_banner", "false") != "true" {
+		banner.Hide()
+		return
+	}
+	text := snap.Category
+	if snap.Description != "" {
+		text += " — " + reporting.TruncateRunes(snap.Description, workBannerDescriptionLength)
+	}
+	if snap.State == domain.Paused {
+		text = "Paused: " + text
+		banner.Importance = widget.WarningImportance
+	} else {
+		banner.Importance = widget.HighImportance
+	}
+	banner.SetText(text)
+	banner.Show()
+}
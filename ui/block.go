@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// showLogBlockDialog records a fixed-length block of work ending now, such as a
+// standup, without running the timer. onLogged is called after it is saved.
+func showLogBlockDialog(w fyne.Window, state *domain.AppState, categories []string, onLogged func()) {
+	categorySelect := widget.NewSelect(categories, nil)
+	categorySelect.PlaceHolder = "Select category"
+	descEntry := widget.NewEntry()
+	descEntry.PlaceHolder = "Optional"
+	durationEntry := widget.NewEntry()
+	durationEntry.PlaceHolder = "e.g. 30m or 1h15m"
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Category", categorySelect),
+		widget.NewFormItem("Description", descEntry),
+		widget.NewFormItem("Duration", durationEntry),
+	}
+	d := dialog.NewForm("Log fixed block", "Log", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		dur, err := domain.ParseDurationInput(durationEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		category, description := categorySelect.Selected, strings.TrimSpace(descEntry.Text)
+		save := func() {
+			if err := state.LogBlock(category, description, dur); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			onLogged()
+		}
+
+		// Warn before recording the same time twice
+		end := time.Now()
+		overlaps, err := storage.FindOverlaps(state.DB, end.Add(-dur), end)
+		if err != nil {
+			notifyError(w, "Log block error", err)
+			return
+		}
+		if len(overlaps) == 0 {
+			save()
+			return
+		}
+		dialog.ShowConfirm("Overlapping time",
+			fmt.Sprintf("A %s block ending now overlaps time that is already recorded:\n\n%s\n\nLog it anyway?",
+				dur, formatOverlaps(overlaps)),
+			func(ok bool) {
+				if ok {
+					save()
+				}
+			}, w)
+	}, w)
+	d.Resize(fyne.NewSize(420, d.MinSize().Height))
+	d.Show()
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// newMonthlyBudgetsForm builds the Settings rows for per-category monthly
+// budgets. onChanged is called after a budget is saved or cleared.
+func newMonthlyBudgetsForm(w fyne.Window, state *domain.AppState, categories []string, onChanged func()) fyne.CanvasObject {
+	grid := container.NewGridWithColumns(2)
+	for _, cat := range categories {
+		key := domain.MonthlyBudgetSettingKey(cat)
+		entry := widget.NewEntry()
+		entry.PlaceHolder = "e.g. 40h (empty for none)"
+		entry.SetText(storage.GetSetting(state.DB, key, ""))
+		entry.OnChanged = func(text string) {
+			text = strings.TrimSpace(text)
+			if text != "" {
+				if d, err := domain.ParseDurationInput(text); err != nil || d <= 0 {
+					return // keep the stored budget until the entry is valid
+				}
+			}
+			if err := storage.SetSetting(state.DB, key, text); err != nil {
+				notifyError(w, "Failed to save setting", err)
+				return
+			}
+			onChanged()
+		}
+		grid.Add(widget.NewLabel(cat))
+		grid.Add(entry)
+	}
+	return grid
+}
+
+// monthlyBudgetRows renders the month containing now for each category with a
+// budget: used and remaining time plus a bar that fills as the budget burns.
+// Over-budget categories are shown as danger.
+func monthlyBudgetRows(state *domain.AppState, categories []string, now time.Time) ([]fyne.CanvasObject, error) {
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	last := first.AddDate(0, 1, -1)
+	totals, err := reporting.TotalsByDayAndCategory(state.DB, first.Format("2006-01-02"), last.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	used := map[string]time.Duration{}
+	for _, t := range totals {
+		used[t.Category] += time.Duration(t.TotalSeconds) * time.Second
+	}
+
+	round := state.RoundToNearestMinute
+	var rows []fyne.CanvasObject
+	for _, cat := range categories {
+		budget, err := domain.ParseDurationInput(storage.GetSetting(state.DB, domain.MonthlyBudgetSettingKey(cat), ""))
+		if err != nil || budget <= 0 {
+			continue
+		}
+		spent := used[cat]
+		l := widget.NewLabel("")
+		if spent > budget {
+			l.Importance = widget.DangerImportance
+			l.SetText(fmt.Sprintf("%s: %s of %s used, over by %s", cat,
+				reporting.FormatDuration(spent, round), reporting.FormatDuration(budget, round),
+				reporting.FormatDuration(spent-budget, round)))
+		} else {
+			l.SetText(fmt.Sprintf("%s: %s of %s used, %s left", cat,
+				reporting.FormatDuration(spent, round), reporting.FormatDuration(budget, round),
+				reporting.FormatDuration(budget-spent, round)))
+		}
+		bar := widget.NewProgressBar()
+		bar.SetValue(min(float64(spent)/float64(budget), 1))
+		rows = append(rows, l, bar)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, widget.NewLabel("No monthly budgets set (Settings → Goals)."))
+	}
+	return rows, nil
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// removedCategorySuffix marks a category that has recorded work but is no
+// longer among the configured options.
+const removedCategorySuffix = " (removed)"
+
+// selectCategory selects category in sel, whose regular choices are options.
+// A category missing from options, e.g. that of a restored session whose
+// category was removed, is offered as "<name> (removed)" until the next call,
+// so the selection never shows blank or a value outside the list.
+func selectCategory(sel *widget.Select, options []string, category string) {
+	if category == "" || slices.Contains(options, category) {
+		sel.Options = options
+		sel.SetSelected(category)
+		return
+	}
+	sel.Options = append(slices.Clip(options), category+removedCategorySuffix)
+	sel.SetSelected(category + removedCategorySuffix)
+}
+
+// selectedCategory returns the category chosen in sel without the removed marker.
+func selectedCategory(sel *widget.Select) string {
+	return strings.TrimSuffix(sel.Selected, removedCategorySuffix)
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+)
+
+// Choices for what closing the window does to work in progress.
+const (
+	closeActionAsk   = "Ask"
+	closeActionKeep  = "Keep running in background"
+	closeActionPause = "Pause"
+	closeActionStop  = "Stop"
+)
+
+var closeActions = []string{closeActionAsk, closeActionKeep, closeActionPause, closeActionStop}
+
+// applyCloseAction pauses or stops work as chosen before the window closes.
+// Keeping it running needs nothing: the open interval stays open.
+func applyCloseAction(w fyne.Window, state *domain.AppState, action string) {
+	var err error
+	switch action {
+	case closeActionPause:
+		err = state.PauseWork()
+	case closeActionStop:
+		err = state.StopWork()
+	}
+	if err != nil && !errors.Is(err, domain.ErrIntervalDiscarded) {
+		notifyError(w, "Close error", err)
+	}
+}
+
+// showCloseDialog asks what to do with work in progress when the window is
+// closed. closeApp is called after the chosen action; Cancel keeps the window open.
+func showCloseDialog(w fyne.Window, state *domain.AppState, closeApp func()) {
+	msg := widget.NewLabel("Work is in progress. Time keeps being counted while Timeclock is closed unless you pause or stop it.")
+	msg.Wrapping = fyne.TextWrapWord
+
+	var d *dialog.CustomDialog
+	choose := func(action string) func() {
+		return func() {
+			d.Hide()
+			applyCloseAction(w, state, action)
+			closeApp()
+		}
+	}
+	d = dialog.NewCustomWithoutButtons("Close Timeclock", msg, w)
+	d.SetButtons([]fyne.CanvasObject{
+		widget.NewButton("Keep running", choose(closeActionKeep)),
+		widget.NewButton("Pause", choose(closeActionPause)),
+		widget.NewButton("Stop", choose(closeActionStop)),
+		widget.NewButton("Cancel", d.Hide),
+	})
+	d.Resize(fyne.NewSize(460, d.MinSize().Height))
+	d.Show()
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// continueSessionLimit is how many recent sessions are offered for continuing.
+const continueSessionLimit = 20
+
+// showContinueSessionDialog lists recently stopped sessions and reopens the chosen
+// one. onContinued is called after the session is running again.
+func showContinueSessionDialog(w fyne.Window, state *domain.AppState, onContinued func()) {
+	if state.Snapshot().State != domain.Stopped {
+		notifyError(w, "Continue unavailable", fmt.Errorf("stop the current session first"))
+		return
+	}
+	sessions, err := storage.RecentSessions(state.DB, continueSessionLimit)
+	if err != nil {
+		notifyError(w, "Continue error", err)
+		return
+	}
+
+	// Sessions are picked by row, since two can share a label
+	var stopped []storage.SessionInfo
+	var labels []string
+	for _, s := range sessions {
+		if !s.Stopped() {
+			continue
+		}
+		label := fmt.Sprintf("%s  %s  %s", s.LastEventUTC.Local().Format("2006-01-02 15:04"), s.Category, s.Description)
+		if s.Label != "" {
+			label += "  [" + s.Label + "]"
+		}
+		stopped = append(stopped, s)
+		labels = append(labels, label)
+	}
+	if len(stopped) == 0 {
+		dialog.ShowInformation("Continue session", "There are no stopped sessions to continue.", w)
+		return
+	}
+
+	selected := 0
+	list := widget.NewList(
+		func() int { return len(labels) },
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(labels[id])
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) { selected = id }
+	list.Select(selected)
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(480, 200))
+	content := container.NewBorder(widget.NewLabel("Stopped at"), nil, nil, nil, scroll)
+	d := dialog.NewCustomConfirm("Continue session", "Continue", "Cancel", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := state.ContinueSession(stopped[selected].ID); err != nil {
+			notifyError(w, "Continue error", err)
+			return
+		}
+		onContinued()
+	}, w)
+	d.Show()
+}
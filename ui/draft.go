@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/1kaius1/Timeclock/logging"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// descriptionDraftDelay is how long typing must pause before the draft is saved.
+const descriptionDraftDelay = 2 * time.Second
+
+// descriptionDraft keeps the description typed before Start in the
+// "description_draft" setting, so a crash before Start doesn't lose it.
+// Saving is debounced and can be switched off with "autosave_description_draft".
+type descriptionDraft struct {
+	db    *sql.DB
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+func newDescriptionDraft(db *sql.DB) *descriptionDraft {
+	return &descriptionDraft{db: db}
+}
+
+// enabled reports whether drafts are saved (the default).
+func (d *descriptionDraft) enabled() bool {
+	return storage.GetSetting(d.db, "autosave_description_draft", "true") == "true"
+}
+
+// load returns the saved draft, or "" if there is none or drafts are off.
+func (d *descriptionDraft) load() string {
+	if !d.enabled() {
+		return ""
+	}
+	return storage.GetSetting(d.db, "description_draft", "")
+}
+
+// changed schedules text to be saved once typing pauses.
+func (d *descriptionDraft) changed(text string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(descriptionDraftDelay, func() {
+		if !d.enabled() {
+			return
+		}
+		if err := storage.SetSetting(d.db, "description_draft", text); err != nil {
+			logging.Warnf("save description draft: %v", err)
+		}
+	})
+}
+
+// clear drops any pending save and the stored draft, e.g. once work starts.
+func (d *descriptionDraft) clear() {
+	d.mu.Lock()
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+	if err := storage.SetSetting(d.db, "description_draft", ""); err != nil {
+		logging.Warnf("clear description draft: %v", err)
+	}
+}
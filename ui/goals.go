@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// goalKindLabels maps the choices in the per-category goal selector.
+var goalKindLabels = map[string]domain.GoalKind{
+	"At least": domain.GoalAtLeast,
+	"At most":  domain.GoalAtMost,
+}
+
+const noGoalLabel = "No goal"
+
+// newCategoryGoalsForm builds the Settings rows for per-category daily goals.
+// onChanged is called after a goal is saved or cleared.
+func newCategoryGoalsForm(w fyne.Window, state *domain.AppState, categories []string, onChanged func()) fyne.CanvasObject {
+	grid := container.NewGridWithColumns(3)
+	for _, cat := range categories {
+		key := domain.CategoryGoalSettingKey(cat)
+		kindSelect := widget.NewSelect([]string{noGoalLabel, "At least", "At most"}, nil)
+		targetEntry := widget.NewEntry()
+		targetEntry.PlaceHolder = "e.g. 4h"
+
+		kindSelect.SetSelected(noGoalLabel)
+		if g, err := domain.ParseCategoryGoal(storage.GetSetting(state.DB, key, "")); err == nil {
+			for label, kind := range goalKindLabels {
+				if kind == g.Kind {
+					kindSelect.SetSelected(label)
+				}
+			}
+			targetEntry.SetText(reporting.FormatDuration(g.Target, true))
+		}
+
+		save := func() {
+			value := ""
+			if kind, ok := goalKindLabels[kindSelect.Selected]; ok {
+				d, err := domain.ParseDurationInput(targetEntry.Text)
+				if err != nil || d <= 0 {
+					return // keep the stored goal until the entry is valid
+				}
+				value = domain.CategoryGoal{Kind: kind, Target: d}.String()
+			}
+			if err := storage.SetSetting(state.DB, key, value); err != nil {
+				notifyError(w, "Failed to save setting", err)
+				return
+			}
+			onChanged()
+		}
+		kindSelect.OnChanged = func(string) { save() }
+		targetEntry.OnChanged = func(string) { save() }
+
+		grid.Add(widget.NewLabel(cat))
+		grid.Add(kindSelect)
+		grid.Add(targetEntry)
+	}
+	return grid
+}
+
+// categoryGoalLines renders today's total per category against its goal, one
+// label per category that has a goal or any time today. Met goals are shown as
+// success, missed ones as danger; categories without a goal are left plain.
+func categoryGoalLines(state *domain.AppState, categories []string) ([]fyne.CanvasObject, error) {
+	today := storage.ReportDate(state.DB, time.Now())
+	totals, err := reporting.TotalsByDayAndCategory(state.DB, today, today)
+	if err != nil {
+		return nil, err
+	}
+	byCategory := map[string]time.Duration{}
+	for _, t := range totals {
+		byCategory[t.Category] = time.Duration(t.TotalSeconds) * time.Second
+	}
+
+	round := state.RoundToNearestMinute
+	var lines []fyne.CanvasObject
+	for _, cat := range categories {
+		total, worked := byCategory[cat]
+		g, err := domain.ParseCategoryGoal(storage.GetSetting(state.DB, domain.CategoryGoalSettingKey(cat), ""))
+		hasGoal := err == nil
+		if !hasGoal && !worked {
+			continue
+		}
+
+		text := fmt.Sprintf("%-14s : %s", cat, reporting.FormatDuration(total, round))
+		l := widget.NewLabel("")
+		if hasGoal {
+			bound := "at least"
+			if g.Kind == domain.GoalAtMost {
+				bound = "at most"
+			}
+			delta := total - g.Target
+			sign := "+"
+			if delta < 0 {
+				sign, delta = "-", -delta
+			}
+			text += fmt.Sprintf("  (goal %s %s, %s%s)", bound, reporting.FormatDuration(g.Target, round), sign, reporting.FormatDuration(delta, round))
+			l.Importance = widget.DangerImportance
+			if g.Met(total) {
+				l.Importance = widget.SuccessImportance
+			}
+		}
+		l.SetText(strings.TrimSpace(text))
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// underDailyGoal reports whether today's total across all categories is still
+// short of the "daily_goal" setting, and by how much. Without a daily goal it
+// is never under.
+func underDailyGoal(state *domain.AppState) (bool, time.Duration, error) {
+	goal, err := domain.ParseDurationInput(storage.GetSetting(state.DB, "daily_goal", ""))
+	if err != nil || goal <= 0 {
+		return false, 0, nil
+	}
+	today := storage.ReportDate(state.DB, time.Now())
+	totals, err := reporting.TotalsByDay(state.DB, today, today)
+	if err != nil {
+		return false, 0, err
+	}
+	var total time.Duration
+	for _, t := range totals {
+		total += time.Duration(t.TotalSeconds) * time.Second
+	}
+	return total < goal, goal - total, nil
+}
+
+// goalCountdown tracks today's progress towards the "daily_goal" setting. The
+// closed total is read from the database by refresh; the running interval is
+// added by text on every tick. Only used on the UI goroutine.
+type goalCountdown struct {
+	date       string        // date the closed total belongs to (see storage.ReportDate)
+	start, end time.Time     // the instants date runs between
+	closed     time.Duration // closed work on date
+	goal       time.Duration // 0 when no daily goal is set
+}
+
+// refresh reloads the goal and today's closed total.
+func (c *goalCountdown) refresh(state *domain.AppState) error {
+	c.goal, _ = domain.ParseDurationInput(storage.GetSetting(state.DB, "daily_goal", ""))
+	c.date = storage.ReportDate(state.DB, time.Now())
+	var err error
+	c.start, c.end, err = storage.DayBounds(c.date, storage.ReportLocation(state.DB), storage.DayBoundary(state.DB))
+	if err != nil {
+		return err
+	}
+	totals, err := reporting.TotalsByDay(state.DB, c.date, c.date)
+	if err != nil {
+		return err
+	}
+	c.closed = 0
+	for _, t := range totals {
+		c.closed += time.Duration(t.TotalSeconds) * time.Second
+	}
+	return nil
+}
+
+// text renders "2h 10m to goal" or "Goal met! +15m" for the closed total plus
+// the running interval's share of today; "" without a goal.
+func (c *goalCountdown) text(snap domain.StateSnapshot, now time.Time, round bool) string {
+	if c.goal <= 0 {
+		return ""
+	}
+	total := c.closed
+	if snap.State == domain.InProgress {
+		total += min(snap.Elapsed, now.Sub(c.start))
+	}
+	if total >= c.goal {
+		return "Goal met! +" + reporting.FormatDuration(total-c.goal, round)
+	}
+	return reporting.FormatDuration(c.goal-total, round) + " to goal"
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// csvImporter is storage.ImportTogglCSV or one of its siblings.
+type csvImporter func(db *sql.DB, r io.Reader, appVersion string, skipOverlaps, dryRun bool) (imported, skipped int, overlaps []storage.CSVImportOverlap, err error)
+
+// importTimeEntries imports another tracker's CSV export from r. The file is
+// first checked with a dry run; entries overlapping time already recorded are
+// listed for confirmation before anything is written, saying whether they
+// will be skipped or imported as skipOverlaps decides. onImported is called
+// after a successful import.
+func importTimeEntries(w fyne.Window, db *sql.DB, r io.Reader, importCSV csvImporter, appVersion string, skipOverlaps bool, onImported func()) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+	_, _, overlaps, err := importCSV(db, bytes.NewReader(data), appVersion, skipOverlaps, true)
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	commit := func() {
+		imported, skipped, overlaps, err := importCSV(db, bytes.NewReader(data), appVersion, skipOverlaps, false)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		onImported()
+		msg := fmt.Sprintf("Entries imported: %d\nEntries skipped: %d", imported, skipped)
+		if len(overlaps) > 0 && skipOverlaps {
+			msg += fmt.Sprintf("\n%d of the skipped entries overlap time already recorded here.", len(overlaps))
+		} else if len(overlaps) > 0 {
+			msg += fmt.Sprintf("\nWarning: %d imported entries overlap time already recorded here and may be counted twice.", len(overlaps))
+		}
+		dialog.ShowInformation("Import complete", msg+"\n\nEntries with no duration or an unreadable start are skipped.", w)
+	}
+	if len(overlaps) == 0 {
+		commit()
+		return
+	}
+
+	action := "They will be imported anyway and may be counted twice."
+	if skipOverlaps {
+		action = "They will be skipped."
+	}
+	intro := widget.NewLabel(fmt.Sprintf("%d entries in the file overlap time already recorded here. %s", len(overlaps), action))
+	intro.Wrapping = fyne.TextWrapWord
+	list := widget.NewLabel(formatImportOverlaps(overlaps))
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(520, 240))
+	dialog.ShowCustomConfirm("Overlapping entries", "Import", "Cancel", container.NewBorder(intro, nil, nil, nil, scroll),
+		func(ok bool) {
+			if ok {
+				commit()
+			}
+		}, w)
+}
+
+// formatImportOverlaps lists each overlapping entry followed by the intervals
+// it overlaps (see formatOverlaps), indented.
+func formatImportOverlaps(overlaps []storage.CSVImportOverlap) string {
+	blocks := make([]string, len(overlaps))
+	for i, o := range overlaps {
+		blocks[i] = fmt.Sprintf("Line %d: %s – %s  %s  %s\n    %s", o.Line,
+			o.StartUTC.Local().Format(dateTimeLayout), o.EndUTC.Local().Format(dateTimeLayout), o.Category, o.Description,
+			strings.ReplaceAll(formatOverlaps(o.Existing), "\n", "\n    "))
+	}
+	return strings.Join(blocks, "\n\n")
+}
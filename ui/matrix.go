@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/1kaius1/Timeclock/reporting"
+)
+
+// dayCategoryMatrix arranges day/category totals as a grid with days as rows,
+// categories as columns, and a trailing total row and column.
+type dayCategoryMatrix struct {
+	days       []string
+	categories []string
+	cells      map[string]map[string]int64
+	dayTotals  map[string]int64
+	catTotals  map[string]int64
+	grandTotal int64
+}
+
+func newDayCategoryMatrix(totals []reporting.DayCategoryTotal) *dayCategoryMatrix {
+	m := &dayCategoryMatrix{
+		cells:     map[string]map[string]int64{},
+		dayTotals: map[string]int64{},
+		catTotals: map[string]int64{},
+	}
+	for _, t := range totals {
+		if _, ok := m.cells[t.Date]; !ok {
+			m.cells[t.Date] = map[string]int64{}
+			m.days = append(m.days, t.Date)
+		}
+		if _, ok := m.catTotals[t.Category]; !ok {
+			m.categories = append(m.categories, t.Category)
+		}
+		m.cells[t.Date][t.Category] += t.TotalSeconds
+		m.dayTotals[t.Date] += t.TotalSeconds
+		m.catTotals[t.Category] += t.TotalSeconds
+		m.grandTotal += t.TotalSeconds
+	}
+	sort.Strings(m.days)
+	sort.Strings(m.categories)
+	return m
+}
+
+// size returns the table dimensions including the header row/column and totals.
+func (m *dayCategoryMatrix) size() (rows, cols int) {
+	return len(m.days) + 2, len(m.categories) + 2
+}
+
+// cellText returns the text for a table cell. Row 0 and column 0 are headers;
+// the last row and column hold totals. Empty cells are left blank.
+func (m *dayCategoryMatrix) cellText(row, col int, roundToMinute bool) string {
+	rows, cols := m.size()
+	lastRow, lastCol := row == rows-1, col == cols-1
+
+	switch {
+	case row == 0 && col == 0:
+		return "Date"
+	case row == 0 && lastCol:
+		return "Total"
+	case row == 0:
+		return m.categories[col-1]
+	case col == 0 && lastRow:
+		return "Total"
+	case col == 0:
+		return m.days[row-1]
+	}
+
+	var secs int64
+	switch {
+	case lastRow && lastCol:
+		secs = m.grandTotal
+	case lastRow:
+		secs = m.catTotals[m.categories[col-1]]
+	case lastCol:
+		secs = m.dayTotals[m.days[row-1]]
+	default:
+		secs = m.cells[m.days[row-1]][m.categories[col-1]]
+	}
+	if secs == 0 {
+		return ""
+	}
+	return reporting.FormatDuration(time.Duration(secs)*time.Second, roundToMinute)
+}
@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+// setAlwaysOnTop asks the window manager to keep w above other windows.
+// Fyne has no portable API for this, so it goes through the native window
+// handle; platforms without support silently ignore the request.
+func setAlwaysOnTop(w fyne.Window, onTop bool) {
+	nw, ok := w.(driver.NativeWindow)
+	if !ok {
+		return
+	}
+	nw.RunNative(func(context any) {
+		setNativeAlwaysOnTop(context, onTop)
+	})
+}
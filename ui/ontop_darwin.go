@@ -0,0 +1,29 @@
+package ui
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework AppKit
+
+#import <stdint.h>
+#import <AppKit/AppKit.h>
+
+static void timeclockSetFloating(uintptr_t window, int onTop) {
+	NSWindow *w = (NSWindow *)window;
+	[w setLevel:(onTop ? NSFloatingWindowLevel : NSNormalWindowLevel)];
+}
+*/
+import "C"
+
+import "fyne.io/fyne/v2/driver"
+
+func setNativeAlwaysOnTop(context any, onTop bool) {
+	mc, ok := context.(driver.MacWindowContext)
+	if !ok || mc.NSWindow == 0 {
+		return
+	}
+	var flag C.int
+	if onTop {
+		flag = 1
+	}
+	C.timeclockSetFloating(C.uintptr_t(mc.NSWindow), flag)
+}
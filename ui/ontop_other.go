@@ -0,0 +1,7 @@
+//go:build !windows && !darwin && (wayland || !(linux || freebsd || openbsd || netbsd))
+
+package ui
+
+// Wayland and other platforms give applications no way to raise themselves
+// above other windows, so the preference is a no-op there.
+func setNativeAlwaysOnTop(context any, onTop bool) {}
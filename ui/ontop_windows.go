@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"syscall"
+
+	"fyne.io/fyne/v2/driver"
+)
+
+var procSetWindowPos = syscall.NewLazyDLL("user32.dll").NewProc("SetWindowPos")
+
+const (
+	hwndTopmost   = ^uintptr(0) // HWND_TOPMOST (-1)
+	hwndNoTopmost = ^uintptr(1) // HWND_NOTOPMOST (-2)
+
+	swpNoSize     = 0x0001
+	swpNoMove     = 0x0002
+	swpNoActivate = 0x0010
+)
+
+func setNativeAlwaysOnTop(context any, onTop bool) {
+	wc, ok := context.(driver.WindowsWindowContext)
+	if !ok || wc.HWND == 0 {
+		return
+	}
+	insertAfter := hwndNoTopmost
+	if onTop {
+		insertAfter = hwndTopmost
+	}
+	procSetWindowPos.Call(wc.HWND, insertAfter, 0, 0, 0, 0, swpNoMove|swpNoSize|swpNoActivate)
+}
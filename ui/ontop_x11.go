@@ -0,0 +1,48 @@
+//go:build !wayland && (linux || freebsd || openbsd || netbsd)
+
+package ui
+
+/*
+#cgo LDFLAGS: -lX11
+#include <string.h>
+#include <X11/Xlib.h>
+
+// timeclockSetAbove asks the EWMH window manager to add or remove
+// _NET_WM_STATE_ABOVE on the given window.
+static void timeclockSetAbove(unsigned long window, int above) {
+	Display *display = XOpenDisplay(NULL);
+	if (display == NULL) {
+		return;
+	}
+
+	XEvent ev;
+	memset(&ev, 0, sizeof(ev));
+	ev.xclient.type = ClientMessage;
+	ev.xclient.window = (Window)window;
+	ev.xclient.message_type = XInternAtom(display, "_NET_WM_STATE", False);
+	ev.xclient.format = 32;
+	ev.xclient.data.l[0] = above ? 1 : 0; // _NET_WM_STATE_ADD / _NET_WM_STATE_REMOVE
+	ev.xclient.data.l[1] = XInternAtom(display, "_NET_WM_STATE_ABOVE", False);
+	ev.xclient.data.l[3] = 1; // source indication: normal application
+
+	XSendEvent(display, DefaultRootWindow(display), False,
+		SubstructureRedirectMask | SubstructureNotifyMask, &ev);
+	XFlush(display);
+	XCloseDisplay(display);
+}
+*/
+import "C"
+
+import "fyne.io/fyne/v2/driver"
+
+func setNativeAlwaysOnTop(context any, onTop bool) {
+	xc, ok := context.(driver.X11WindowContext)
+	if !ok || xc.WindowHandle == 0 {
+		return
+	}
+	var flag C.int
+	if onTop {
+		flag = 1
+	}
+	C.timeclockSetAbove(C.ulong(xc.WindowHandle), flag)
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// paletteCommand is one action offered by the command palette.
+type paletteCommand struct {
+	name      string           // what the user types, e.g. "report this week"
+	argHint   string           // placeholder for the argument, "" if it takes none
+	available func() bool      // whether the command can run now; nil means always
+	run       func(arg string) // arg is the text after name, trimmed
+}
+
+// paletteMatch is a command matching the palette input, with its argument.
+type paletteMatch struct {
+	cmd paletteCommand
+	arg string
+}
+
+func (m paletteMatch) label() string {
+	switch {
+	case m.arg != "":
+		return m.cmd.name + " " + m.arg
+	case m.cmd.argHint != "":
+		return m.cmd.name + " [" + m.cmd.argHint + "]"
+	}
+	return m.cmd.name
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in s in order,
+// ignoring case, so "rtw" matches "report this week".
+func fuzzyMatch(s, pattern string) bool {
+	s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	for _, r := range pattern {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// matchPaletteCommands returns the available commands matching input, best
+// first: a command taking an argument followed by one ("start Project"), then
+// name prefixes, then fuzzy matches. Empty input lists every available command.
+func matchPaletteCommands(commands []paletteCommand, input string) []paletteMatch {
+	input = strings.TrimSpace(input)
+	lower := strings.ToLower(input)
+	var withArg, prefix, fuzzy []paletteMatch
+	for _, c := range commands {
+		if c.available != nil && !c.available() {
+			continue
+		}
+		switch {
+		case c.argHint != "" && strings.HasPrefix(lower, c.name+" "):
+			withArg = append(withArg, paletteMatch{cmd: c, arg: strings.TrimSpace(input[len(c.name):])})
+		case strings.HasPrefix(c.name, lower):
+			prefix = append(prefix, paletteMatch{cmd: c})
+		case fuzzyMatch(c.name, input):
+			fuzzy = append(fuzzy, paletteMatch{cmd: c})
+		}
+	}
+	return append(append(withArg, prefix...), fuzzy...)
+}
+
+// matchCategory resolves a typed category against options: an exact match
+// ignoring case first, then a prefix, then a fuzzy match.
+func matchCategory(options []string, typed string) (string, bool) {
+	for _, match := range []func(o string) bool{
+		func(o string) bool { return strings.EqualFold(o, typed) },
+		func(o string) bool { return strings.HasPrefix(strings.ToLower(o), strings.ToLower(typed)) },
+		func(o string) bool { return fuzzyMatch(o, typed) },
+	} {
+		for _, o := range options {
+			if match(o) {
+				return o, true
+			}
+		}
+	}
+	return "", false
+}
+
+// showCommandPalette opens a dialog with an entry that filters commands as the
+// user types. Enter runs the first match; tapping a row runs that one.
+func showCommandPalette(w fyne.Window, commands []paletteCommand) {
+	var d *dialog.CustomDialog
+	var matches []paletteMatch
+
+	list := widget.NewList(
+		func() int { return len(matches) },
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(matches[id].label())
+		},
+	)
+	run := func(m paletteMatch) {
+		d.Hide()
+		m.cmd.run(m.arg)
+	}
+	list.OnSelected = func(id widget.ListItemID) {
+		if id < len(matches) {
+			run(matches[id])
+		}
+	}
+
+	entry := widget.NewEntry()
+	entry.PlaceHolder = "Type a command, e.g. start Project"
+	entry.OnChanged = func(text string) {
+		matches = matchPaletteCommands(commands, text)
+		list.UnselectAll()
+		list.Refresh()
+	}
+	entry.OnSubmitted = func(text string) {
+		if len(matches) > 0 {
+			run(matches[0])
+		}
+	}
+	entry.OnChanged("")
+
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(360, 200))
+	d = dialog.NewCustomWithoutButtons("Commands", container.NewBorder(entry, nil, nil, nil, scroll), w)
+	d.SetButtons([]fyne.CanvasObject{widget.NewButton("Close", func() { d.Hide() })})
+	d.Show()
+	w.Canvas().Focus(entry)
+}
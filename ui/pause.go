@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+)
+
+// pauseReasons are offered as one-click answers in the pause reason prompt.
+var pauseReasons = []string{"Lunch", "Meeting", "Interruption"}
+
+// showPauseReasonDialog asks why work was paused and stores the answer on the
+// PAUSE event that was just written. Skipping leaves the reason empty.
+func showPauseReasonDialog(w fyne.Window, state *domain.AppState, onDone func()) {
+	var d *dialog.CustomDialog
+
+	save := func(reason string) {
+		if err := state.SetPauseReason(strings.TrimSpace(reason)); err != nil {
+			notifyError(w, "Pause reason error", err)
+		}
+		d.Hide()
+		onDone()
+	}
+
+	quick := container.NewHBox()
+	for _, r := range pauseReasons {
+		reason := r
+		quick.Add(widget.NewButton(reason, func() { save(reason) }))
+	}
+
+	otherEntry := widget.NewEntry()
+	otherEntry.PlaceHolder = "Other reason..."
+	otherEntry.OnSubmitted = save
+
+	content := container.NewVBox(
+		widget.NewLabel("Why are you pausing? (optional)"),
+		quick,
+		container.NewBorder(nil, nil, nil, widget.NewButton("Save", func() { save(otherEntry.Text) }), otherEntry),
+	)
+	d = dialog.NewCustomWithoutButtons("Pause reason", content, w)
+	d.SetButtons([]fyne.CanvasObject{widget.NewButton("Skip", func() {
+		d.Hide()
+		onDone()
+	})})
+	d.Show()
+}
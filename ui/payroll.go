@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// payrollCodeSettingKey is the setting holding the payroll project code for a category.
+func payrollCodeSettingKey(category string) string {
+	return storage.PayrollCodeKeyPrefix + category
+}
+
+// payrollMapping loads the category -> project code mapping. Categories without
+// a code are left out.
+func payrollMapping(state *domain.AppState, categories []string) map[string]string {
+	mapping := map[string]string{}
+	for _, cat := range categories {
+		if code := storage.GetSetting(state.DB, payrollCodeSettingKey(cat), ""); code != "" {
+			mapping[cat] = code
+		}
+	}
+	return mapping
+}
+
+// newPayrollCodesForm builds the Settings rows mapping each category to its
+// payroll project code. Codes are saved as they are typed.
+func newPayrollCodesForm(w fyne.Window, state *domain.AppState, categories []string) fyne.CanvasObject {
+	grid := container.NewGridWithColumns(2)
+	for _, cat := range categories {
+		key := payrollCodeSettingKey(cat)
+		codeEntry := widget.NewEntry()
+		codeEntry.PlaceHolder = cat
+		codeEntry.SetText(storage.GetSetting(state.DB, key, ""))
+		codeEntry.OnChanged = func(text string) {
+			if err := storage.SetSetting(state.DB, key, strings.TrimSpace(text)); err != nil {
+				notifyError(w, "Failed to save setting", err)
+			}
+		}
+		grid.Add(widget.NewLabel(cat))
+		grid.Add(codeEntry)
+	}
+	return grid
+}
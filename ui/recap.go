@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+)
+
+// showWeeklyRecapDialog shows the plain-text recap for the week containing
+// weekDate ('YYYY-MM-DD') with a button to copy it to the clipboard.
+func showWeeklyRecapDialog(a fyne.App, w fyne.Window, state *domain.AppState, weekDate string) {
+	recap, err := reporting.WeeklyRecap(state.DB, weekDate)
+	if err != nil {
+		notifyError(w, "Recap error", err)
+		return
+	}
+
+	text := widget.NewLabel(recap)
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+	scroll := container.NewScroll(text)
+	scroll.SetMinSize(fyne.NewSize(480, 320))
+
+	d := dialog.NewCustomWithoutButtons("Weekly recap", scroll, w)
+	copyBtn := widget.NewButton("Copy", func() {
+		a.Clipboard().SetContent(recap)
+	})
+	copyBtn.Importance = widget.HighImportance
+	d.SetButtons([]fyne.CanvasObject{copyBtn, widget.NewButton("Close", d.Hide)})
+	d.Show()
+}
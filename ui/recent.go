@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// recentEventsLimit is how many events the recent activity list shows.
+const recentEventsLimit = 5
+
+// recentColumns are the columns the recent activity list can show, in order.
+var recentColumns = []string{"Time", "Action", "Category", "Label", "Description", "Duration"}
+
+// recentTimeLayouts maps the time format choices to Go layouts.
+var recentTimeLayouts = map[string]string{
+	"Date and time": "2006-01-02 15:04:05",
+	"Time only":     "15:04:05",
+}
+
+// recentEventsPrefs controls how the recent activity list is rendered.
+type recentEventsPrefs struct {
+	columns    map[string]bool // visible columns
+	descLength int             // descriptions longer than this are truncated
+	timeLayout string
+}
+
+func loadRecentEventsPrefs(db *sql.DB) recentEventsPrefs {
+	p := recentEventsPrefs{columns: map[string]bool{}}
+	for _, c := range strings.Split(storage.GetSetting(db, "recent_columns", strings.Join(recentColumns, ",")), ",") {
+		p.columns[c] = true
+	}
+	p.descLength, _ = strconv.Atoi(storage.GetSetting(db, "recent_description_length", "30"))
+	if p.descLength < 4 {
+		p.descLength = 30
+	}
+	p.timeLayout = recentTimeLayouts[storage.GetSetting(db, "recent_time_format", "Date and time")]
+	if p.timeLayout == "" {
+		p.timeLayout = recentTimeLayouts["Date and time"]
+	}
+	return p
+}
+
+// truncate shortens desc to the configured length (see reporting.TruncateRunes).
+func (p recentEventsPrefs) truncate(desc string) string {
+	return reporting.TruncateRunes(desc, p.descLength)
+}
+
+// newRecentEventTemplate is the list item: one label per possible column.
+func newRecentEventTemplate() fyne.CanvasObject {
+	cells := make([]fyne.CanvasObject, len(recentColumns))
+	for i := range cells {
+		l := widget.NewLabel("template")
+		l.Truncation = fyne.TextTruncateEllipsis
+		cells[i] = l
+	}
+	return container.New(layout.NewGridLayoutWithColumns(len(recentColumns)), cells...)
+}
+
+// recentLoadFailed replaces the list when the events can't be read.
+const recentLoadFailed = "Failed to load recent activity."
+
+// showRecentPlaceholder makes list show a single row holding text.
+func showRecentPlaceholder(list *widget.List, text string) {
+	list.Length = func() int { return 1 }
+	list.UpdateItem = func(_ widget.ListItemID, obj fyne.CanvasObject) {
+		row := obj.(*fyne.Container)
+		for i, o := range row.Objects {
+			l := o.(*widget.Label)
+			if i == 0 {
+				l.SetText(text)
+				l.Show()
+			} else {
+				l.Hide()
+			}
+		}
+		row.Layout = layout.NewGridLayoutWithColumns(1)
+		row.Refresh()
+	}
+	list.Refresh()
+}
+
+// updateRecentEventItem fills a list item with cells (indexed like recentColumns),
+// hiding the columns that are switched off.
+func updateRecentEventItem(obj fyne.CanvasObject, cells []string, p recentEventsPrefs) {
+	row := obj.(*fyne.Container)
+	visible := 0
+	for i, col := range recentColumns {
+		l := row.Objects[i].(*widget.Label)
+		if !p.columns[col] {
+			l.Hide()
+			continue
+		}
+		visible++
+		l.SetText(cells[i])
+		l.Show()
+	}
+	if visible == 0 {
+		visible = 1
+	}
+	row.Layout = layout.NewGridLayoutWithColumns(visible)
+	row.Refresh()
+}
+
+// newRecentEventsSettings builds the Settings controls for the recent activity
+// list; onChanged is called after a preference is saved.
+func newRecentEventsSettings(w fyne.Window, db *sql.DB, onChanged func()) fyne.CanvasObject {
+	p := loadRecentEventsPrefs(db)
+
+	columnsCheck := widget.NewCheckGroup(recentColumns, nil)
+	columnsCheck.Horizontal = true
+	var selected []string
+	for _, c := range recentColumns {
+		if p.columns[c] {
+			selected = append(selected, c)
+		}
+	}
+	columnsCheck.SetSelected(selected)
+	columnsCheck.OnChanged = func(selected []string) {
+		if err := storage.SetSetting(db, "recent_columns", strings.Join(selected, ",")); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		onChanged()
+	}
+
+	lengthEntry := widget.NewEntry()
+	lengthEntry.SetText(strconv.Itoa(p.descLength))
+	lengthEntry.OnChanged = func(text string) {
+		n, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil || n < 4 {
+			return
+		}
+		if err := storage.SetSetting(db, "recent_description_length", strconv.Itoa(n)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		onChanged()
+	}
+
+	timeFormatSelect := widget.NewSelect([]string{"Date and time", "Time only"}, nil)
+	timeFormatSelect.SetSelected(storage.GetSetting(db, "recent_time_format", "Date and time"))
+	timeFormatSelect.OnChanged = func(choice string) {
+		if err := storage.SetSetting(db, "recent_time_format", choice); err != nil {
+			notifyError(w, "Failed to save setting", err)
+			return
+		}
+		onChanged()
+	}
+
+	return container.NewVBox(
+		columnsCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("Truncate descriptions after:"), widget.NewLabel("characters (min 4)"), lengthEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Time format:"), nil, timeFormatSelect),
+		widget.NewLabel(fmt.Sprintf("Shows the last %d events on the Track tab.", recentEventsLimit)),
+	)
+}
+
+// showLiveSessionActions offers the controls of the running or paused session
+// from its row in the recent activity list. Pause and Stop tap the Track tab's
+// buttons, so they go through the same AppState calls and follow-up prompts,
+// and are only offered while those buttons are enabled.
+func showLiveSessionActions(w fyne.Window, pauseBtn, stopBtn *widget.Button, showDetail func()) {
+	var d *dialog.CustomDialog
+	action := func(run func()) func() {
+		return func() {
+			d.Hide()
+			run()
+		}
+	}
+	pause := widget.NewButton("Pause", action(pauseBtn.OnTapped))
+	if pauseBtn.Disabled() {
+		pause.Disable()
+	}
+	stop := widget.NewButton("Stop", action(stopBtn.OnTapped))
+	if stopBtn.Disabled() {
+		stop.Disable()
+	}
+	stop.Importance = widget.DangerImportance
+
+	d = dialog.NewCustomWithoutButtons("Current session", widget.NewLabel("This is the session you are tracking now."), w)
+	d.SetButtons([]fyne.CanvasObject{
+		pause,
+		stop,
+		widget.NewButton("View Session Detail", action(showDetail)),
+		widget.NewButton("Close", func() { d.Hide() }),
+	})
+	d.Show()
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// defaultStaleRestoreHours is the "stale_restore_hours" default.
+const defaultStaleRestoreHours = 8
+
+// showRestoreDialog tells the user that an interrupted InProgress session was
+// restored and lets them keep it running, stop it now, or stop it at a chosen
+// time. onStopped is called after the session has been stopped. Nothing is shown
+// if no interval is open any more.
+//
+// An interval running longer than the "stale_restore_hours" setting most likely
+// outlived a crash, so the dialog then suggests stopping at its last checkpoint
+// and asks for confirmation before keeping it running.
+func showRestoreDialog(w fyne.Window, state *domain.AppState, onStopped func()) {
+	// One consistent read of the interval, as the ticker may run meanwhile
+	open, ok := state.CurrentInterval()
+	if !ok {
+		return
+	}
+	start, elapsed := open.StartUTC.Local(), open.Elapsed
+
+	staleHours, err := strconv.Atoi(storage.GetSetting(state.DB, "stale_restore_hours", strconv.Itoa(defaultStaleRestoreHours)))
+	if err != nil || staleHours < 0 {
+		staleHours = defaultStaleRestoreHours
+	}
+	stale := staleHours > 0 && elapsed > time.Duration(staleHours)*time.Hour
+	// A forgotten interval can run for days, so honour the days setting
+	elapsedText := reporting.FormatDurationWith(elapsed, reporting.DurationFormat{
+		RoundToMinute: true,
+		ShowDays:      storage.GetSetting(state.DB, "duration_days", "false") == "true",
+	})
+
+	text := fmt.Sprintf(
+		"Timeclock was closed while \"%s\" was in progress.\n\nThe current interval started %s and has been running for %s.\nWhat should happen to it?",
+		open.Category, start.Format("Mon 2006-01-02 15:04"), elapsedText)
+	stopAt := time.Now()
+	if stale {
+		if last := state.RestoredLastSeen; !last.IsZero() && last.After(open.StartUTC) {
+			stopAt = last
+			text += fmt.Sprintf("\n\nThat is unusually long. Timeclock last saw it running at %s; stopping there is suggested.",
+				last.Local().Format("Mon 2006-01-02 15:04"))
+		} else {
+			text += "\n\nThat is unusually long; check the stop time before keeping it."
+		}
+	}
+	msg := widget.NewLabel(text)
+	msg.Wrapping = fyne.TextWrapWord
+
+	stopAtEntry := widget.NewEntry()
+	stopAtEntry.SetText(stopAt.Local().Format(dateTimeLayout))
+
+	var d *dialog.CustomDialog
+
+	keepBtn := widget.NewButton("Keep running", func() {
+		if !stale {
+			d.Hide()
+			return
+		}
+		dialog.ShowConfirm("Keep counting?",
+			fmt.Sprintf("Keep all %s of this interval and continue counting?", elapsedText),
+			func(ok bool) {
+				if ok {
+					d.Hide()
+				}
+			}, w)
+	})
+	stopNowBtn := widget.NewButton("Stop now", func() {
+		if err := state.StopWork(); err != nil && !errors.Is(err, domain.ErrIntervalDiscarded) {
+			notifyError(w, "Stop error", err)
+			return
+		}
+		d.Hide()
+		onStopped()
+	})
+	stopAtBtn := widget.NewButton("Stop at time", func() {
+		at, err := parseLocalDateTime(stopAtEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if err := state.StopWorkAt(at); errors.Is(err, domain.ErrInvalidStopTime) {
+			dialog.ShowError(err, w)
+			return
+		} else if err != nil && !errors.Is(err, domain.ErrIntervalDiscarded) {
+			notifyError(w, "Stop error", err)
+			return
+		}
+		d.Hide()
+		onStopped()
+	})
+	if stale {
+		stopAtBtn.Importance = widget.HighImportance
+	}
+
+	content := container.NewVBox(
+		msg,
+		container.NewBorder(nil, nil, widget.NewLabel("Stop at:"), nil, stopAtEntry),
+	)
+	d = dialog.NewCustomWithoutButtons("Session restored", content, w)
+	d.SetButtons([]fyne.CanvasObject{keepBtn, stopNowBtn, stopAtBtn})
+	d.Show()
+}
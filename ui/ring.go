@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+)
+
+// ringSpinnerSweep is the length in degrees of the segment shown when there is
+// no target to fill towards; ringSpinnerStep is how far it moves per update.
+const (
+	ringSpinnerSweep = 60
+	ringSpinnerStep  = 30
+)
+
+// progressRing is a circular gauge of elapsed time against a target. Without a
+// target it shows a rotating segment instead, so a running timer still looks alive.
+type progressRing struct {
+	widget.BaseWidget
+
+	track *canvas.Arc
+	fill  *canvas.Arc
+	spin  float32 // spinner position in degrees
+}
+
+func newProgressRing() *progressRing {
+	r := &progressRing{
+		track: canvas.NewArc(0, 360, 0.75, theme.Color(theme.ColorNameInputBackground)),
+		fill:  canvas.NewArc(0, 0, 0.75, theme.Color(theme.ColorNamePrimary)),
+	}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// update redraws the ring for the current interval. running is false while
+// paused or stopped, which hides the spinner. A target of 0 means none.
+func (r *progressRing) update(elapsed, target time.Duration, running bool) {
+	switch {
+	case target > 0:
+		frac := float32(elapsed) / float32(target)
+		if frac > 1 {
+			frac = 1
+		}
+		r.fill.StartAngle, r.fill.EndAngle = 0, 360*frac
+		r.fill.FillColor = theme.Color(theme.ColorNamePrimary)
+		if elapsed >= target {
+			// Target reached: switch to the amber used for "take a break"
+			r.fill.FillColor = stateColor(domain.Paused)
+		}
+	case running:
+		r.spin += ringSpinnerStep
+		if r.spin >= 360 {
+			r.spin -= 360
+		}
+		r.fill.StartAngle, r.fill.EndAngle = r.spin, r.spin+ringSpinnerSweep
+		r.fill.FillColor = theme.Color(theme.ColorNamePrimary)
+	default:
+		r.fill.StartAngle, r.fill.EndAngle = 0, 0
+	}
+	r.fill.Refresh()
+}
+
+func (r *progressRing) CreateRenderer() fyne.WidgetRenderer {
+	return &progressRingRenderer{ring: r}
+}
+
+type progressRingRenderer struct {
+	ring *progressRing
+}
+
+func (rr *progressRingRenderer) Layout(size fyne.Size) {
+	side := fyne.Min(size.Width, size.Height)
+	pos := fyne.NewPos((size.Width-side)/2, (size.Height-side)/2)
+	for _, a := range []*canvas.Arc{rr.ring.track, rr.ring.fill} {
+		a.Move(pos)
+		a.Resize(fyne.NewSquareSize(side))
+	}
+}
+
+func (rr *progressRingRenderer) MinSize() fyne.Size {
+	return fyne.NewSquareSize(48)
+}
+
+func (rr *progressRingRenderer) Refresh() {
+	rr.ring.track.FillColor = theme.Color(theme.ColorNameInputBackground)
+	rr.ring.track.Refresh()
+	rr.ring.fill.Refresh()
+}
+
+func (rr *progressRingRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{rr.ring.track, rr.ring.fill}
+}
+
+func (rr *progressRingRenderer) Destroy() {}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// runScheduledExport writes last week's report to the "export_schedule_dir"
+// directory when scheduled exports are enabled and that week has not been
+// exported yet, then records the week in "export_schedule_last_week". It
+// returns the written path, or "" when there was nothing to do.
+func runScheduledExport(db *sql.DB, now time.Time) (string, error) {
+	if storage.GetSetting(db, "export_schedule_enabled", "false") != "true" {
+		return "", nil
+	}
+	dir := storage.GetSetting(db, "export_schedule_dir", "")
+	if dir == "" {
+		return "", nil
+	}
+	week, from, to := reporting.PreviousWeek(now)
+	if storage.GetSetting(db, "export_schedule_last_week", "") == week {
+		return "", nil
+	}
+	format := storage.GetSetting(db, "export_schedule_format", "CSV")
+	path, err := reporting.WriteWeeklyExport(db, dir, format, week, from, to)
+	if err != nil {
+		return "", err
+	}
+	if err := storage.SetSetting(db, "export_schedule_last_week", week); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// newScheduledExportSettings builds the Settings controls for the weekly export.
+func newScheduledExportSettings(w fyne.Window, db *sql.DB) fyne.CanvasObject {
+	enabledCheck := widget.NewCheck("Export last week's report every Monday", nil)
+	enabledCheck.SetChecked(storage.GetSetting(db, "export_schedule_enabled", "false") == "true")
+	enabledCheck.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(db, "export_schedule_enabled", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	dirEntry := widget.NewEntry()
+	dirEntry.PlaceHolder = "Folder to write reports to"
+	dirEntry.SetText(storage.GetSetting(db, "export_schedule_dir", ""))
+	dirEntry.OnChanged = func(text string) {
+		if err := storage.SetSetting(db, "export_schedule_dir", strings.TrimSpace(text)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+	browseBtn := widget.NewButton("Browse...", func() {
+		dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err != nil {
+				notifyError(w, "Folder error", err)
+				return
+			}
+			if uri != nil {
+				dirEntry.SetText(uri.Path())
+			}
+		}, w)
+	})
+
+	formatSelect := widget.NewSelect(reporting.ScheduledExportFormats, nil)
+	formatSelect.SetSelected(storage.GetSetting(db, "export_schedule_format", "CSV"))
+	formatSelect.OnChanged = func(choice string) {
+		if err := storage.SetSetting(db, "export_schedule_format", choice); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+	}
+
+	lastWeek := storage.GetSetting(db, "export_schedule_last_week", "")
+	if lastWeek == "" {
+		lastWeek = "never"
+	}
+	return container.NewVBox(
+		enabledCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("Folder:"), browseBtn, dirEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Format:"), nil, formatSelect),
+		widget.NewLabel("Files are named by ISO week, e.g. timeclock-2024-W07.csv. Last exported: "+lastWeek),
+	)
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/logging"
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// showSessionDetailDialog shows a session's totals and each of its intervals.
+// Closed intervals can be split in two; the dialog reopens with the result. A
+// stopped session can be moved to the trash, after which onTrashed is called.
+func showSessionDetailDialog(w fyne.Window, state *domain.AppState, sessionID string, categories []string, onTrashed func()) {
+	summary, intervals, err := reporting.SessionDetail(state.DB, sessionID)
+	if err == sql.ErrNoRows {
+		dialog.ShowInformation("Session", "This session has no recorded intervals.", w)
+		return
+	}
+	if err != nil {
+		notifyError(w, "Session error", err)
+		return
+	}
+
+	var d dialog.Dialog
+	round := state.RoundToNearestMinute
+	lines := container.NewVBox()
+	for _, iv := range intervals {
+		end, dur := "running", "-"
+		if !iv.EndUTC.IsZero() {
+			end = iv.EndUTC.Local().Format("15:04:05")
+			dur = reporting.FormatDuration(time.Duration(iv.DurationSeconds)*time.Second, round)
+		}
+		l := widget.NewLabel(fmt.Sprintf("#%d  %s – %s  %s  %s  %s",
+			iv.Index+1, iv.StartUTC.Local().Format("2006-01-02 15:04:05"), end, dur, iv.Category, iv.Description))
+		l.Wrapping = fyne.TextWrapWord
+		if iv.EndUTC.IsZero() {
+			lines.Add(l)
+			continue
+		}
+		iv := iv
+		splitBtn := widget.NewButton("Split…", func() {
+			showSplitIntervalDialog(w, state, iv, categories, func() {
+				d.Hide()
+				showSessionDetailDialog(w, state, sessionID, categories, onTrashed)
+			})
+		})
+		lines.Add(container.NewBorder(nil, nil, nil, splitBtn, l))
+	}
+
+	title := sessionID
+	if summary.Label != "" {
+		title = fmt.Sprintf("%s (%s)", summary.Label, sessionID)
+	}
+	header := widget.NewLabel(fmt.Sprintf("Session %s\nWorked: %s in %d closed interval(s)\nSpan: %s, of which breaks: %s",
+		title, reporting.FormatDuration(time.Duration(summary.TotalSeconds)*time.Second, round), summary.Intervals,
+		reporting.FormatDuration(time.Duration(summary.SpanSeconds)*time.Second, round),
+		reporting.FormatDuration(time.Duration(summary.BreakSeconds())*time.Second, round)))
+	header.Wrapping = fyne.TextWrapWord
+
+	scroll := container.NewVScroll(lines)
+	scroll.SetMinSize(fyne.NewSize(520, 240))
+	// Diagnostic: confirm no time was lost or duplicated slicing into days
+	verifyBtn := widget.NewButton("Verify Day Slicing", func() {
+		ok, discrepancies, err := reporting.VerifyDaySlicing(state.DB, sessionID)
+		if err != nil {
+			notifyError(w, "Verify error", err)
+			return
+		}
+		if ok {
+			dialog.ShowInformation("Day slicing", "Every closed interval's daily slices add up and cover consecutive dates.", w)
+			return
+		}
+		dialog.ShowInformation("Day slicing", "Found problems (Rebuild daily data... in Settings re-slices every interval):\n\n"+strings.Join(discrepancies, "\n"), w)
+	})
+	actions := container.NewHBox(verifyBtn)
+	// Which releases wrote this session, to correlate anomalies with versions
+	if versions, err := reporting.SessionAppVersions(state.DB, sessionID); err != nil {
+		logging.Warnf("read versions of session %s: %v", sessionID, err)
+	} else if len(versions) > 0 {
+		actions.Add(widget.NewLabel("Written by: " + strings.Join(versions, ", ")))
+	}
+	if info, err := storage.GetSession(state.DB, sessionID); err == nil && info.Stopped() {
+		trashBtn := widget.NewButton("Move to Trash", func() {
+			confirmTrashSession(w, state, sessionID, func() {
+				d.Hide()
+				onTrashed()
+			})
+		})
+		actions.Add(trashBtn)
+	}
+	content := container.NewBorder(header, actions, nil, nil, scroll)
+	d = dialog.NewCustom("Session detail", "Close", content, w)
+	d.Show()
+}
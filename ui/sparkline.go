@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"database/sql"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/reporting"
+)
+
+// sparklineDays is how many trailing days (including today) the sparkline shows.
+const sparklineDays = 7
+
+// sparkline is a small bar chart of daily totals, oldest on the left. The last
+// bar is today and is drawn in the primary color.
+type sparkline struct {
+	widget.BaseWidget
+
+	bars    []*canvas.Rectangle
+	seconds []int64
+}
+
+func newSparkline() *sparkline {
+	s := &sparkline{seconds: make([]int64, sparklineDays)}
+	for i := 0; i < sparklineDays; i++ {
+		s.bars = append(s.bars, canvas.NewRectangle(theme.Color(theme.ColorNameDisabled)))
+	}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// load refreshes the bars from the recorded totals of the trailing week.
+func (s *sparkline) load(db *sql.DB) error {
+	today := time.Now()
+	from := today.AddDate(0, 0, -(sparklineDays - 1)).Format("2006-01-02")
+	days, err := reporting.TotalsByDay(db, from, today.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+	byDate := make(map[string]int64, len(days))
+	for _, d := range days {
+		byDate[d.Date] = d.TotalSeconds
+	}
+	for i := range s.seconds {
+		date := today.AddDate(0, 0, i-(sparklineDays-1)).Format("2006-01-02")
+		s.seconds[i] = byDate[date]
+	}
+	s.Refresh()
+	return nil
+}
+
+func (s *sparkline) CreateRenderer() fyne.WidgetRenderer {
+	return &sparklineRenderer{line: s}
+}
+
+type sparklineRenderer struct {
+	line *sparkline
+	size fyne.Size
+}
+
+func (r *sparklineRenderer) Layout(size fyne.Size) {
+	r.size = size
+	var max int64
+	for _, secs := range r.line.seconds {
+		if secs > max {
+			max = secs
+		}
+	}
+
+	gap := float32(2)
+	n := float32(len(r.line.bars))
+	width := (size.Width - gap*(n-1)) / n
+	for i, bar := range r.line.bars {
+		height := float32(1) // keep empty days visible as a baseline
+		if max > 0 && r.line.seconds[i] > 0 {
+			height = fyne.Max(height, size.Height*float32(r.line.seconds[i])/float32(max))
+		}
+		bar.Move(fyne.NewPos(float32(i)*(width+gap), size.Height-height))
+		bar.Resize(fyne.NewSize(width, height))
+	}
+}
+
+func (r *sparklineRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(float32(sparklineDays)*8, 24)
+}
+
+func (r *sparklineRenderer) Refresh() {
+	last := len(r.line.bars) - 1
+	for i, bar := range r.line.bars {
+		bar.FillColor = theme.Color(theme.ColorNameDisabled)
+		if i == last {
+			bar.FillColor = theme.Color(theme.ColorNamePrimary)
+		}
+	}
+	r.Layout(r.size)
+	for _, bar := range r.line.bars {
+		bar.Refresh()
+	}
+}
+
+func (r *sparklineRenderer) Objects() []fyne.CanvasObject {
+	objs := make([]fyne.CanvasObject, len(r.line.bars))
+	for i, bar := range r.line.bars {
+		objs[i] = bar
+	}
+	return objs
+}
+
+func (r *sparklineRenderer) Destroy() {}
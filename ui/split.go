@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+)
+
+// showSplitIntervalDialog splits a closed interval into two categories at a
+// chosen time. onSplit is called after a successful change.
+func showSplitIntervalDialog(w fyne.Window, state *domain.AppState, iv reporting.IntervalDetail, categories []string, onSplit func()) {
+	mid := iv.StartUTC.Add(iv.EndUTC.Sub(iv.StartUTC) / 2)
+	atEntry := widget.NewEntry()
+	atEntry.SetText(mid.Local().Format(dateTimeLayout))
+
+	firstSelect := widget.NewSelect(categories, nil)
+	firstSelect.SetSelected(iv.Category)
+	secondSelect := widget.NewSelect(categories, nil)
+	secondSelect.SetSelected(iv.Category)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Entry", widget.NewLabel(fmt.Sprintf("%s – %s  %s",
+			iv.StartUTC.Local().Format(dateTimeLayout), iv.EndUTC.Local().Format("15:04:05"), iv.Description))),
+		widget.NewFormItem("Split at", atEntry),
+		widget.NewFormItem("Before", firstSelect),
+		widget.NewFormItem("After", secondSelect),
+	}
+	d := dialog.NewForm("Split entry", "Split", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		at, err := parseLocalDateTime(atEntry.Text)
+		if err != nil {
+			notifyError(w, "Invalid time", err)
+			return
+		}
+		if err := state.SplitInterval(iv.ID, at, firstSelect.Selected, secondSelect.Selected); err != nil {
+			notifyError(w, "Split error", err)
+			return
+		}
+		onSplit()
+	}, w)
+	d.Resize(fyne.NewSize(420, d.MinSize().Height))
+	d.Show()
+}
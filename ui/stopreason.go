@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+)
+
+// showStopReasonDialog asks why work stopped short of the day's goal and stores
+// the answer on the session's STOP event. Skipping leaves the reason empty.
+func showStopReasonDialog(w fyne.Window, state *domain.AppState, sessionID string, short time.Duration, onDone func()) {
+	var d *dialog.CustomDialog
+
+	reasonEntry := widget.NewEntry()
+	reasonEntry.PlaceHolder = "Reason..."
+	save := func(reason string) {
+		if err := state.SetStopReason(sessionID, strings.TrimSpace(reason)); err != nil {
+			notifyError(w, "Stop reason error", err)
+		}
+		d.Hide()
+		onDone()
+	}
+	reasonEntry.OnSubmitted = save
+
+	msg := widget.NewLabel(fmt.Sprintf("You stopped %s short of today's goal. Why?",
+		reporting.FormatDuration(short, state.RoundToNearestMinute)))
+	msg.Wrapping = fyne.TextWrapWord
+	content := container.NewVBox(msg, reasonEntry)
+
+	d = dialog.NewCustomWithoutButtons("Stopping under goal", content, w)
+	d.SetButtons([]fyne.CanvasObject{
+		widget.NewButton("Skip", func() {
+			d.Hide()
+			onDone()
+		}),
+		widget.NewButton("Save", func() { save(reasonEntry.Text) }),
+	})
+	d.Resize(fyne.NewSize(400, d.MinSize().Height))
+	d.Show()
+	w.Canvas().Focus(reasonEntry)
+}
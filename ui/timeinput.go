@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateTimeLayout is how local date/times are shown in editable fields.
+// Parsing also accepts the same layout without seconds.
+const dateTimeLayout = "2006-01-02 15:04:05"
+
+// parseLocalDateTime parses "YYYY-MM-DD HH:MM[:SS]" in the local timezone.
+func parseLocalDateTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{dateTimeLayout, "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date/time in the form YYYY-MM-DD HH:MM", s)
+}
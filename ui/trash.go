@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/domain"
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// confirmTrashSession moves a stopped session to the trash after confirmation.
+// onTrashed is called once it is gone from reports.
+func confirmTrashSession(w fyne.Window, state *domain.AppState, sessionID string, onTrashed func()) {
+	dialog.ShowConfirm("Move to Trash",
+		"Move this session to the trash? It will be left out of reports until you restore it from Settings.",
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := storage.TrashSession(state.DB, sessionID); err != nil {
+				notifyError(w, "Trash error", err)
+				return
+			}
+			onTrashed()
+		}, w)
+}
+
+// newTrashView lists trashed sessions with buttons to restore or permanently
+// delete each. The returned refresh reloads the list; onChanged is called after
+// a restore or purge.
+func newTrashView(w fyne.Window, state *domain.AppState, onChanged func()) (fyne.CanvasObject, func()) {
+	box := container.NewVBox()
+	var refresh func()
+	refresh = func() {
+		trashed, err := storage.TrashedSessions(state.DB)
+		if err != nil {
+			notifyError(w, "Trash error", err)
+			return
+		}
+		box.Objects = nil
+		if len(trashed) == 0 {
+			box.Add(widget.NewLabel("(Trash is empty)"))
+		}
+		for _, t := range trashed {
+			id := t.ID
+			label := widget.NewLabel(fmt.Sprintf("%s  %s  %s  %s",
+				t.StartUTC.Local().Format("2006-01-02 15:04"), t.Category,
+				reporting.FormatDuration(time.Duration(t.TotalSeconds)*time.Second, state.RoundToNearestMinute), t.Description))
+			label.Truncation = fyne.TextTruncateEllipsis
+			restoreBtn := widget.NewButton("Restore", func() {
+				if err := storage.RestoreSession(state.DB, id); err != nil {
+					notifyError(w, "Restore error", err)
+					return
+				}
+				refresh()
+				onChanged()
+			})
+			purgeBtn := widget.NewButton("Delete", func() {
+				dialog.ShowConfirm("Delete permanently",
+					"Permanently delete this session? This cannot be undone.",
+					func(ok bool) {
+						if !ok {
+							return
+						}
+						if err := storage.PurgeSession(state.DB, id); err != nil {
+							notifyError(w, "Delete error", err)
+							return
+						}
+						refresh()
+						onChanged()
+					}, w)
+			})
+			purgeBtn.Importance = widget.DangerImportance
+			box.Add(container.NewBorder(nil, nil, nil, container.NewHBox(restoreBtn, purgeBtn), label))
+		}
+		box.Refresh()
+	}
+	refresh()
+	return box, refresh
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/1kaius1/Timeclock/reporting"
+	"github.com/1kaius1/Timeclock/storage"
+)
+
+// formatUsageStats renders UsageStats as a few lines of plain text.
+func formatUsageStats(u reporting.UsageStats) string {
+	if u.Sessions == 0 {
+		return "No completed sessions yet."
+	}
+	typical := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Add(u.TypicalStart)
+	return fmt.Sprintf("Sessions: %d (%.1f per week)\nAverage session: %s\nMost common category: %s (%s)\nTypical start: %s",
+		u.Sessions, u.SessionsPerWeek,
+		reporting.FormatDuration(time.Duration(u.AverageSessionSeconds)*time.Second, true),
+		u.TopCategory, reporting.FormatDuration(time.Duration(u.TopCategorySeconds)*time.Second, true),
+		typical.Format("15:04"))
+}
+
+// newUsagePatternsView shows a local summary of tracking habits once the user
+// opts in; nothing leaves the machine. The returned refresh recomputes it.
+func newUsagePatternsView(w fyne.Window, db *sql.DB) (fyne.CanvasObject, func()) {
+	output := widget.NewLabel("")
+	check := widget.NewCheck("Show my usage patterns (computed locally)", nil)
+	check.SetChecked(storage.GetSetting(db, "show_usage_patterns", "false") == "true")
+
+	refresh := func() {
+		if !check.Checked {
+			output.Hide()
+			return
+		}
+		stats, err := reporting.UsagePatterns(db)
+		if err != nil {
+			notifyError(w, "Usage patterns error", err)
+			return
+		}
+		output.SetText(formatUsageStats(stats))
+		output.Show()
+	}
+	check.OnChanged = func(checked bool) {
+		if err := storage.SetSetting(db, "show_usage_patterns", fmt.Sprintf("%t", checked)); err != nil {
+			notifyError(w, "Failed to save setting", err)
+		}
+		refresh()
+	}
+	return container.NewVBox(check, output), refresh
+}